@@ -1,9 +1,12 @@
-package main
+package atoz
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -18,16 +21,31 @@ type ApiSpec struct {
 type Action struct {
 	Name        string     `json:"name"`
 	Ref         string     `json:"ref"`
+	Method      string     `json:"method,omitempty"`
 	Uri         string     `json:"uri"`
 	Description string     `json:"description"`
 	Notes       []string   `json:"notes"`
 	Parameters  []KeyValue `json:"parameters"`
 	Returns     []KeyValue `json:"returns"`
+
+	// Set when any return is qualified with a status code, keyed by the code.
+	Responses map[string]Response `json:"responses,omitempty"`
+
+	// Only set when examples are requested.
+	ExampleRequest  interface{} `json:"exampleRequest,omitempty"`
+	ExampleResponse interface{} `json:"exampleResponse,omitempty"`
+
+	// The @ref line of the action.
+	Source *Source `json:"source,omitempty"`
+
+	// Set by an @deprecated line.
+	Deprecated bool `json:"deprecated,omitempty"`
 }
 
 func (a Action) String() string {
 	returnString := "\tName: " + a.Name + "\n" +
 		"\tRef: " + a.Ref + "\n" +
+		"\tMethod: " + a.Method + "\n" +
 		"\tUri: " + a.Uri + "\n" +
 		"\tDescription: " + a.Description + "\n"
 
@@ -52,12 +70,23 @@ func (a Action) String() string {
 	return returnString
 }
 
+type Response struct {
+	Description string     `json:"description"`
+	Returns     []KeyValue `json:"returns"`
+}
+
 type Object struct {
 	Name        string     `json:"name"`
 	Ref         string     `json:"ref"`
 	Description string     `json:"description"`
 	Notes       []string   `json:"notes"`
 	Properties  []KeyValue `json:"properties"`
+
+	// The @ref line of the object.
+	Source *Source `json:"source,omitempty"`
+
+	// Set by an @deprecated line.
+	Deprecated bool `json:"deprecated,omitempty"`
 }
 
 func (o Object) String() string {
@@ -87,6 +116,20 @@ type KeyValue struct {
 	Limit       int64      `json:"limit"`
 	Description string     `json:"description"`
 	Children    []KeyValue `json:"children"`
+
+	// The JSON value of an @example line - empty when there isn't one, so an
+	// example of null can be told apart.
+	Example json.RawMessage `json:"example,omitempty"`
+
+	// Where a parameter is sent - "path", "query" or "header".  Blank for the
+	// JSON body, as well as for returns and properties.
+	Location string `json:"location,omitempty"`
+
+	// The line that declared the key/value.
+	Source *Source `json:"source,omitempty"`
+
+	// The ref of the object a {#/Ref#} type points at, once it's been found.
+	ResolvedRef string `json:"resolvedRef,omitempty"`
 }
 
 func (k KeyValue) String() string {
@@ -124,60 +167,135 @@ func (a ObjectByName) Len() int           { return len(a) }
 func (a ObjectByName) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 func (a ObjectByName) Less(i, j int) bool { return a[i].Name < a[j].Name }
 
-const (
-	startDefinition = "---ATOZDEF---"
-	startAction     = "---ATOZAPI---"
-	startObject     = "---ATOZOBJ---"
-	endDefinition   = "---ATOZEND---"
-)
+// Where a line was read from.
+type Source struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
 
-func GenerateApiSpec(files []string) (ApiSpec, error) {
-	var err error
+func (s Source) String() string {
+	return s.File + ":" + strconv.Itoa(s.Line)
+}
 
-	groups := make([][]string, 0)
+// Return a copy of the source to attach to a parsed element, or nil when the
+// lines didn't come from a file.
+func (s Source) Pointer() *Source {
+	if len(s.File) == 0 {
+		return nil
+	}
 
-	var definitionGroups map[string][]string
-	var actionGroups map[string][]string
-	var objectGroups map[string][]string
+	return &Source{s.File, s.Line}
+}
 
-	for _, path := range files {
-		file, err := os.Open(path)
-		reader := bufio.NewReader(file)
+// Clear the source of every action, object and key/value, for specs written
+// without them.
+func RemoveSources(apiSpec *ApiSpec) {
+	for i, action := range apiSpec.Actions {
+		apiSpec.Actions[i].Source = nil
+		removeKeyValueSources(action.Parameters)
+		removeKeyValueSources(action.Returns)
 
-		if err != nil {
-			return ApiSpec{}, err
+		for _, response := range action.Responses {
+			removeKeyValueSources(response.Returns)
 		}
+	}
 
-		parseGroupsFiles, parseGroupsErr := ParseGroups(reader)
+	for i, object := range apiSpec.Objects {
+		apiSpec.Objects[i].Source = nil
+		removeKeyValueSources(object.Properties)
+	}
+}
 
-		if parseGroupsErr != nil {
-			return ApiSpec{}, parseGroupsErr
-		}
+func removeKeyValueSources(keyValues []KeyValue) {
+	for i, _ := range keyValues {
+		keyValues[i].Source = nil
+		removeKeyValueSources(keyValues[i].Children)
+	}
+}
 
-		for _, parseGroupFile := range parseGroupsFiles {
-			groups = append(groups, parseGroupFile)
-		}
+// A line of a group along with where it was read from.
+type Line struct {
+	Text   string
+	Source Source
+}
 
-		file.Close()
+// Wrap lines that weren't read from a file - i.e. in tests.
+func NewLines(texts []string) []Line {
+	lines := make([]Line, 0)
+
+	for _, text := range texts {
+		lines = append(lines, Line{text, Source{}})
 	}
 
-	definitionGroups, err = GetDefinitionGroups(groups)
+	return lines
+}
 
-	if err != nil {
-		return ApiSpec{}, err
+func LineTexts(lines []Line) []string {
+	texts := make([]string, 0)
+
+	for _, line := range lines {
+		texts = append(texts, line.Text)
 	}
 
-	actionGroups, err = GetActionGroups(groups)
+	return texts
+}
 
-	if err != nil {
-		return ApiSpec{}, err
+const defaultMethod = "POST"
+
+var methods = map[string]bool{
+	"GET":     true,
+	"POST":    true,
+	"PUT":     true,
+	"PATCH":   true,
+	"DELETE":  true,
+	"HEAD":    true,
+	"OPTIONS": true,
+}
+
+// Actions without an @method line, or a method in their @uri, are sent as a
+// POST - the method is left blank in the JSON.
+func ActionMethod(action Action) string {
+	if len(action.Method) == 0 {
+		return defaultMethod
 	}
 
+	return action.Method
+}
+
+const (
+	startDefinition = "---ATOZDEF---"
+	startAction     = "---ATOZAPI---"
+	startObject     = "---ATOZOBJ---"
+	endDefinition   = "---ATOZEND---"
+)
+
+// Every problem found in the files is collected and returned together as
+// Diagnostics, along with whatever actions and objects could still be built.
+// When lax, a {#/Ref#} type that matches no object is only a warning.
+func GenerateApiSpec(files []string, lax bool) (ApiSpec, error) {
+	var err error
+	var diagnostics Diagnostics
+
+	var groups [][]Line
+	var definitionGroups map[string][]Line
+	var actionGroups map[string][]Line
+	var objectGroups map[string][]Line
+
+	groups, err = ReadGroups(files)
+
+	diagnostics = diagnostics.Append(CodeUnclosedGroup, err)
+
+	definitionGroups, err = GetDefinitionGroups(groups)
+
+	diagnostics = diagnostics.Append(CodeInvalidGroup, err)
+
+	actionGroups, err = GetActionGroups(groups)
+
+	diagnostics = diagnostics.Append(CodeInvalidGroup, err)
+
 	objectGroups, err = GetObjectGroups(groups)
 
-	if err != nil {
-		return ApiSpec{}, err
-	}
+	diagnostics = diagnostics.Append(CodeInvalidGroup, err)
 
 	var action Action
 
@@ -190,7 +308,8 @@ func GenerateApiSpec(files []string) (ApiSpec, error) {
 		action, err = GenerateAction(actionGroup, definitionGroups)
 
 		if err != nil {
-			return ApiSpec{}, err
+			diagnostics = diagnostics.Append(CodeInvalidLine, err)
+			continue
 		}
 
 		apiSpec.Actions = append(apiSpec.Actions, action)
@@ -202,7 +321,8 @@ func GenerateApiSpec(files []string) (ApiSpec, error) {
 		object, err = GenerateObject(objectGroup, definitionGroups)
 
 		if err != nil {
-			return apiSpec, err
+			diagnostics = diagnostics.Append(CodeInvalidLine, err)
+			continue
 		}
 
 		apiSpec.Objects = append(apiSpec.Objects, object)
@@ -211,39 +331,152 @@ func GenerateApiSpec(files []string) (ApiSpec, error) {
 	sort.Stable(ActionByName(apiSpec.Actions))
 	sort.Stable(ObjectByName(apiSpec.Objects))
 
-	return apiSpec, nil
+	diagnostics = diagnostics.Append(CodeDuplicateRoute, CheckDuplicateRoutes(apiSpec.Actions))
+
+	diagnostics = diagnostics.Append(CodeUnknownRef, ResolveRefs(&apiSpec, lax))
+
+	sort.Stable(DiagnosticBySource(diagnostics))
+
+	return apiSpec, diagnostics.Err()
 }
 
-func ParseGroups(r *bufio.Reader) ([][]string, error) {
-	groups := make([][]string, 0)
+// Set the resolvedRef of every {#/Ref#} type to the object it points at.
+func ResolveRefs(apiSpec *ApiSpec, lax bool) error {
+	var diagnostics Diagnostics
+
+	objects := ObjectsByRef(apiSpec.Objects)
+
+	severity := SeverityError
+
+	if lax {
+		severity = SeverityWarning
+	}
+
+	var resolve func(keyValues []KeyValue, owner string)
+
+	resolve = func(keyValues []KeyValue, owner string) {
+		for i, _ := range keyValues {
+			if ref, ok := TypeRef(keyValues[i].Type); ok {
+				if _, ok := objects[ref]; ok {
+					keyValues[i].ResolvedRef = ref
+				} else {
+					diagnostic := SourceError(keyValues[i].Source, CodeUnknownRef, fmt.Errorf("Unknown ref: %s of %s is a %s, which is not an object", keyValues[i].Name, owner, ref)).(Diagnostic)
+					diagnostic.Severity = severity
+
+					diagnostics = diagnostics.Append(CodeUnknownRef, diagnostic)
+				}
+			}
+
+			resolve(keyValues[i].Children, owner)
+		}
+	}
+
+	for _, action := range apiSpec.Actions {
+		resolve(action.Parameters, action.Ref)
+		resolve(action.Returns, action.Ref)
+
+		for _, response := range action.Responses {
+			resolve(response.Returns, action.Ref)
+		}
+	}
+
+	for _, object := range apiSpec.Objects {
+		resolve(object.Properties, object.Ref)
+	}
+
+	return diagnostics.Err()
+}
+
+// Return the groups of every file, along with any files that couldn't be read
+// or had an unclosed group.
+func ReadGroups(files []string) ([][]Line, error) {
+	var diagnostics Diagnostics
+
+	groups := make([][]Line, 0)
+
+	for _, path := range files {
+		file, err := os.Open(path)
+
+		if err != nil {
+			diagnostics = diagnostics.Append(CodeUnreadableFile, SourceError(&Source{path, 0}, CodeUnreadableFile, err))
+			continue
+		}
+
+		parseGroupsFiles, parseGroupsErr := ParseGroups(bufio.NewReader(file), path)
+
+		diagnostics = diagnostics.Append(CodeUnclosedGroup, parseGroupsErr)
+
+		for _, parseGroupFile := range parseGroupsFiles {
+			groups = append(groups, parseGroupFile)
+		}
+
+		file.Close()
+	}
+
+	return groups, diagnostics.Err()
+}
+
+// Two actions can't share both a method and a uri.  A placeholder matches any
+// value, so uris that only differ in the names of their placeholders - such
+// as /users/{id} and /users/{userId} - are the same route.
+func CheckDuplicateRoutes(actions []Action) error {
+	var diagnostics Diagnostics
+
+	routes := make(map[string]string)
+
+	for _, action := range actions {
+		if len(action.Uri) == 0 {
+			continue
+		}
+
+		route := ActionMethod(action) + " " + action.Uri
+		key := ActionMethod(action) + " " + uriPlaceholder.ReplaceAllString(action.Uri, "{}")
+
+		if ref, ok := routes[key]; ok {
+			diagnostics = diagnostics.Append(CodeDuplicateRoute, SourceError(action.Source, CodeDuplicateRoute, fmt.Errorf("Duplicate route: %s is declared by both %s and %s", route, ref, action.Ref)))
+			continue
+		}
+
+		routes[key] = action.Ref
+	}
+
+	return diagnostics.Err()
+}
+
+// Receive a reader and the path it was opened from, which is used to give
+// every line a source.
+func ParseGroups(r *bufio.Reader, file string) ([][]Line, error) {
+	groups := make([][]Line, 0)
 
 	scanner := bufio.NewScanner(r)
 
-	var line string
-	group := make([]string, 0)
+	var line Line
+	var number int
+	group := make([]Line, 0)
 
 	for scanner.Scan() {
-		line = scanner.Text()
+		number++
+		line = Line{scanner.Text(), Source{file, number}}
 
-		if !utf8.ValidString(line) {
-			return make([][]string, 0), nil
+		if !utf8.ValidString(line.Text) {
+			return make([][]Line, 0), nil
 		}
 
-		if strings.Contains(line, startDefinition) ||
-			strings.Contains(line, startAction) ||
-			strings.Contains(line, startObject) {
+		if strings.Contains(line.Text, startDefinition) ||
+			strings.Contains(line.Text, startAction) ||
+			strings.Contains(line.Text, startObject) {
 			group = append(group, line)
-		} else if strings.Contains(line, endDefinition) {
+		} else if strings.Contains(line.Text, endDefinition) {
 			group = append(group, line)
 			groups = append(groups, group)
-			group = make([]string, 0)
+			group = make([]Line, 0)
 		} else if len(group) > 0 {
 			group = append(group, line)
 		}
 	}
 
 	if len(group) > 0 {
-		return nil, fmt.Errorf("Unclosed definition found.")
+		return groups, LineError(group[0], CodeUnclosedGroup, fmt.Errorf("Unclosed definition found."))
 	}
 
 	return groups, nil
@@ -257,16 +490,23 @@ func ParseLineType(line string) (string, error) {
 		"@name":        "name",
 		"@ref":         "ref",
 		"@uri":         "uri",
+		"@method":      "method",
 		"@description": "description",
 		"@note":        "note",
 		"@include":     "include",
+		"@deprecated":  "deprecated",
 		"@parameter":   "parameter",
 		"@required":    "parameter",
 		"@optional":    "parameter",
 		"@return":      "return",
+		"@status":      "status",
 		"@success":     "return",
 		"@failure":     "return",
+		"@path":        "parameter",
+		"@query":       "parameter",
+		"@header":      "parameter",
 		"@property":    "property",
+		"@example":     "example",
 	}
 
 	var returnValue string
@@ -286,7 +526,31 @@ func ParseLineType(line string) (string, error) {
 		return "", fmt.Errorf("Invalid line - missing @declaration." + "\n\t" + line)
 	}
 
-	if returnValue, ok = lineTypes[lineParts[0]]; !ok {
+	declaration, status := splitLineDeclaration(lineParts[0])
+
+	if returnValue, ok = lineTypes[declaration]; ok && len(status) > 0 {
+		if declaration == "@path" {
+			return "", fmt.Errorf("Invalid line - path parameters are always required.\n\t%s", line)
+		}
+
+		if _, ok := locationFlags[declaration]; ok {
+			if status != "required" && status != "optional" {
+				return "", fmt.Errorf("Invalid line - query and header parameters can only be :required or :optional.\n\t%s", line)
+			}
+
+			return returnValue, nil
+		}
+
+		if returnValue != "return" {
+			return "", fmt.Errorf("Invalid line - only returns can have a status code.\n\t%s", line)
+		}
+
+		if !validStatus(status) {
+			return "", fmt.Errorf("Invalid line - invalid status code: %s\n\t%s", status, line)
+		}
+	}
+
+	if !ok {
 		// Check if this is a defined type.
 		if lineParts[0][0:1] == "#" &&
 			lineParts[0][len(lineParts[0])-1:] == "#" {
@@ -299,6 +563,72 @@ func ParseLineType(line string) (string, error) {
 	return returnValue, nil
 }
 
+// Query and header parameters are optional unless they're qualified, as in
+// @header:required - path parameters are always required.
+var locationFlags = map[string]string{
+	"@path":   "required",
+	"@query":  "",
+	"@header": "",
+}
+
+// Receive
+// @return:404 or @header:required
+// Return @return, 404 or @header, required
+func splitLineDeclaration(declaration string) (string, string) {
+	if colonIndex := strings.Index(declaration, ":"); colonIndex > 0 {
+		return declaration[:colonIndex], declaration[colonIndex+1:]
+	}
+
+	return declaration, ""
+}
+
+func validStatus(status string) bool {
+	code, err := strconv.Atoi(status)
+
+	return err == nil && len(status) == 3 && code >= 100 && code < 600
+}
+
+// Receive
+// @return:404 {String} error
+// Return 404
+// Returns a blank string for lines without a status code.
+func ParseLineStatus(line string) string {
+	atIndex := strings.Index(line, "@")
+
+	if atIndex < 0 {
+		return ""
+	}
+
+	lineParts := strings.Split(line[atIndex:], " ")
+
+	_, status := splitLineDeclaration(lineParts[0])
+
+	return status
+}
+
+// Receive
+// @status 404 The user could not be found.
+// Return 404, The user could not be found.
+func ParseLineStatusDescription(line string) (string, string, error) {
+	value, err := ParseLineString(line)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	valueParts := strings.SplitN(value, " ", 2)
+
+	if !validStatus(valueParts[0]) {
+		return "", "", fmt.Errorf("Invalid line - invalid status code: %s\n\t%s", valueParts[0], line)
+	}
+
+	if len(valueParts) < 2 {
+		return valueParts[0], "", nil
+	}
+
+	return valueParts[0], strings.TrimSpace(valueParts[1]), nil
+}
+
 func ParseLineFlag(line string) (string, error) {
 	var lineFlags = map[string]string{
 		"@required": "required",
@@ -361,6 +691,49 @@ func ParseLineString(line string) (string, error) {
 	return returnValue, nil
 }
 
+// Receive
+// @method GET
+// Return GET
+func ParseLineMethod(line string) (string, error) {
+	value, err := ParseLineString(line)
+
+	if err != nil {
+		return "", err
+	}
+
+	method := strings.ToUpper(value)
+
+	if !methods[method] {
+		return "", fmt.Errorf("Invalid method: %s\n\t%s", value, line)
+	}
+
+	return method, nil
+}
+
+// Receive
+// @uri GET /api/user
+// Return GET, /api/user
+// The method is optional, and is returned blank if not provided.
+func ParseLineUri(line string) (string, string, error) {
+	value, err := ParseLineString(line)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	valueParts := strings.Fields(value)
+
+	if methods[strings.ToUpper(valueParts[0])] {
+		if len(valueParts) == 1 {
+			return "", "", fmt.Errorf("Invalid uri - missing the path after the method.\n\t%s", line)
+		}
+
+		return strings.ToUpper(valueParts[0]), strings.Join(valueParts[1:], " "), nil
+	}
+
+	return "", value, nil
+}
+
 // Receive
 // @returns {Type,Limit} Objectspace Description
 // Return Type, Limit, Flag, Objectspace, Description
@@ -404,8 +777,18 @@ func ParseLineKeyValue(line string) (string, int64, string, string, string, erro
 
 	returnFlag = ""
 
-	if _, ok := lineTypeFlags[lineParts[0]]; ok {
-		returnFlag = lineTypeFlags[lineParts[0]]
+	declaration, qualifier := splitLineDeclaration(lineParts[0])
+
+	if _, ok := lineTypeFlags[declaration]; ok {
+		returnFlag = lineTypeFlags[declaration]
+	}
+
+	if flag, ok := locationFlags[declaration]; ok {
+		returnFlag = flag
+
+		if len(flag) == 0 {
+			returnFlag = qualifier
+		}
 	}
 
 	lineType := lineParts[1]
@@ -463,7 +846,13 @@ func ParseLineKeyValue(line string) (string, int64, string, string, string, erro
 		return "", -1, "", "", "", fmt.Errorf("Invalid {} type - must be in format {Type,Limit}." + "\n\t" + line)
 	}
 
-	returnObjectspace = strings.ToLower(lineParts[2])
+	// Query and header parameters are sent by name, so they keep the case
+	// they're declared with.
+	returnObjectspace = lineParts[2]
+
+	if _, ok := locationFlags[declaration]; !ok {
+		returnObjectspace = strings.ToLower(returnObjectspace)
+	}
 
 	returnDescription = ""
 
@@ -474,6 +863,70 @@ func ParseLineKeyValue(line string) (string, int64, string, string, string, erro
 	return returnType, returnLimit, returnFlag, returnObjectspace, returnDescription, nil
 }
 
+// Receive
+// @example Objectspace RawJSON
+// Return Objectspace, Value
+func ParseLineExample(line string) (string, interface{}, error) {
+	var returnObjectspace string
+	var returnValue interface{}
+
+	atIndex := strings.Index(line, "@")
+
+	if atIndex < 0 {
+		return "", nil, fmt.Errorf("Invalid line - missing @declaration.\n\t%s", line)
+	}
+
+	line = line[atIndex:]
+
+	lineParts := strings.Split(line, " ")
+
+	if len(lineParts) < 3 {
+		return "", nil, fmt.Errorf("Invalid line - missing one or more statements.\n\t%s", line)
+	}
+
+	returnObjectspace = strings.ToLower(lineParts[1])
+
+	decoder := json.NewDecoder(strings.NewReader(strings.Join(lineParts[2:], " ")))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&returnValue); err != nil {
+		return "", nil, fmt.Errorf("Invalid example - must be a JSON value. %s\n\t%s", err, line)
+	}
+
+	// Anything but the end of the line after the value is a second value.
+	var extraValue interface{}
+
+	if err := decoder.Decode(&extraValue); err != io.EOF {
+		return "", nil, fmt.Errorf("Invalid example - must be a single JSON value.\n\t%s", line)
+	}
+
+	return returnObjectspace, returnValue, nil
+}
+
+// Receive
+// @header {String} x-auth-token
+// Return "header"
+// Lines that aren't sent in the path, query or headers return a blank string.
+func ParseLineLocation(line string) string {
+	var lineLocations = map[string]string{
+		"@path":   "path",
+		"@query":  "query",
+		"@header": "header",
+	}
+
+	atIndex := strings.Index(line, "@")
+
+	if atIndex < 0 {
+		return ""
+	}
+
+	lineParts := strings.Split(line[atIndex:], " ")
+
+	declaration, _ := splitLineDeclaration(lineParts[0])
+
+	return lineLocations[declaration]
+}
+
 func ParseGroupType(line string) (string, error) {
 	if strings.Contains(line, startDefinition) {
 		return "definition", nil
@@ -488,174 +941,214 @@ func ParseGroupType(line string) (string, error) {
 	return "", fmt.Errorf("Invalid line: no starting group identifier found.")
 }
 
-func ParseGroupRef(group []string) (string, error) {
+func ParseGroupRef(group []Line) (string, error) {
 	var lineType string
 	var err error
 
 	for _, line := range group {
-		lineType, err = ParseLineType(line)
+		lineType, err = ParseLineType(line.Text)
 
 		if err != nil {
-			return "", err
+			return "", LineError(line, CodeInvalidLine, err)
 		}
 
 		if lineType == "ref" {
-			var lineValue, err = ParseLineString(line)
+			var lineValue, err = ParseLineString(line.Text)
 
 			if err != nil {
-				return "", err
+				return "", LineError(line, CodeInvalidLine, err)
 			}
 
 			return lineValue, nil
 		}
 	}
 
-	return "", fmt.Errorf("No line type found in definition."+"\n\t"+"%s", group)
+	return "", fmt.Errorf("No line type found in definition."+"\n\t"+"%s", LineTexts(group))
 }
 
-func GenerateObject(group []string, definitions map[string][]string) (Object, error) {
+func GenerateObject(group []Line, definitions map[string][]Line) (Object, error) {
 	returnObject := Object{}
 
 	var err error
 	var lineType string
 	var defRef string
+	var diagnostics Diagnostics
 
 	for i, line := range group {
-		lineType, err = ParseLineType(line)
+		lineType, err = ParseLineType(line.Text)
 
 		if err != nil {
-			return returnObject, err
+			diagnostics = diagnostics.Append(CodeInvalidLine, LineError(line, CodeInvalidLine, err))
+			continue
 		}
 
 		if lineType == "name" {
-			returnObject.Name, err = ParseLineString(line)
-
-			if err != nil {
-				return returnObject, err
-			}
+			returnObject.Name, err = ParseLineString(line.Text)
 		} else if lineType == "ref" {
-			returnObject.Ref, err = ParseLineString(line)
-
-			if err != nil {
-				return returnObject, err
-			}
+			returnObject.Ref, err = ParseLineString(line.Text)
+			returnObject.Source = line.Source.Pointer()
 		} else if lineType == "description" {
-			returnObject.Description, err = ParseLineString(line)
-
-			if err != nil {
-				return returnObject, err
-			}
+			returnObject.Description, err = ParseLineString(line.Text)
 		} else if lineType == "note" {
-			note, err := ParseLineString(line)
+			var note string
 
-			if err != nil {
-				return returnObject, err
+			note, err = ParseLineString(line.Text)
+
+			if err == nil {
+				returnObject.Notes = append(returnObject.Notes, note)
 			}
+		} else if lineType == "deprecated" {
+			returnObject.Deprecated = true
 
-			returnObject.Notes = append(returnObject.Notes, note)
+			// Anything following @deprecated is kept as a note.
+			if note, noteErr := ParseLineString(line.Text); noteErr == nil {
+				returnObject.Notes = append(returnObject.Notes, note)
+			}
 		} else if lineType == "include" {
-			defRef, err = ParseLineString(line)
+			defRef, err = ParseLineString(line.Text)
 
-			if err != nil {
-				return returnObject, err
-			}
+			if err == nil {
+				if _, ok := definitions[defRef]; !ok {
+					diagnostics = diagnostics.Append(CodeMissingDefinition, LineError(line, CodeMissingDefinition, fmt.Errorf("Definition not found: %s", defRef)))
+					continue
+				}
 
-			if _, ok := definitions[defRef]; !ok {
-				return returnObject, fmt.Errorf("Definition not found: %s", defRef)
+				group = append(group[:i], append(definitions[defRef], group[i:]...)...)
 			}
-
-			group = append(group[:i], append(definitions[defRef], group[i:]...)...)
 		}
+
+		diagnostics = diagnostics.Append(CodeInvalidLine, LineError(line, CodeInvalidLine, err))
 	}
 
 	returnObject.Properties, err = GenerateKeyValues("property", group, "")
 
 	SortKeyValues(returnObject.Properties)
 
-	if err != nil {
-		return returnObject, err
+	diagnostics = diagnostics.Append(CodeInvalidLine, err)
+
+	if err == nil {
+		diagnostics = diagnostics.Append(CodeInvalidExample, ApplyExamples(group, returnObject.Properties))
 	}
 
-	return returnObject, nil
+	return returnObject, diagnostics.Err()
 }
 
-func GenerateAction(group []string, definitions map[string][]string) (Action, error) {
+func GenerateAction(group []Line, definitions map[string][]Line) (Action, error) {
 	returnAction := Action{}
 
 	var err error
 	var lineType string
 	var defRef string
+	var diagnostics Diagnostics
 
 	for i := 0; i < len(group); i++ {
 		line := group[i]
-		lineType, err = ParseLineType(line)
+		lineType, err = ParseLineType(line.Text)
 
 		if err != nil {
-			return returnAction, err
+			diagnostics = diagnostics.Append(CodeInvalidLine, LineError(line, CodeInvalidLine, err))
+			continue
 		}
 
 		if lineType == "name" {
-			returnAction.Name, err = ParseLineString(line)
-
-			if err != nil {
-				return returnAction, err
-			}
+			returnAction.Name, err = ParseLineString(line.Text)
 		} else if lineType == "ref" {
-			returnAction.Ref, err = ParseLineString(line)
-
-			if err != nil {
-				return returnAction, err
-			}
+			returnAction.Ref, err = ParseLineString(line.Text)
+			returnAction.Source = line.Source.Pointer()
 		} else if lineType == "uri" {
-			returnAction.Uri, err = ParseLineString(line)
+			var method string
 
-			if err != nil {
-				return returnAction, err
+			method, returnAction.Uri, err = ParseLineUri(line.Text)
+
+			if err == nil && len(method) > 0 {
+				if len(returnAction.Method) > 0 && returnAction.Method != method {
+					diagnostics = diagnostics.Append(CodeConflictingMethod, LineError(line, CodeConflictingMethod, fmt.Errorf("Conflicting methods: %s and %s\n\t%s", returnAction.Method, method, line.Text)))
+					continue
+				}
+
+				returnAction.Method = method
 			}
-		} else if lineType == "description" {
-			returnAction.Description, err = ParseLineString(line)
+		} else if lineType == "method" {
+			var method string
 
-			if err != nil {
-				return returnAction, err
+			method, err = ParseLineMethod(line.Text)
+
+			if err == nil {
+				if len(returnAction.Method) > 0 && returnAction.Method != method {
+					diagnostics = diagnostics.Append(CodeConflictingMethod, LineError(line, CodeConflictingMethod, fmt.Errorf("Conflicting methods: %s and %s\n\t%s", returnAction.Method, method, line.Text)))
+					continue
+				}
+
+				returnAction.Method = method
 			}
+		} else if lineType == "description" {
+			returnAction.Description, err = ParseLineString(line.Text)
 		} else if lineType == "note" {
-			note, err := ParseLineString(line)
+			var note string
 
-			if err != nil {
-				return returnAction, err
+			note, err = ParseLineString(line.Text)
+
+			if err == nil {
+				returnAction.Notes = append(returnAction.Notes, note)
 			}
+		} else if lineType == "deprecated" {
+			returnAction.Deprecated = true
 
-			returnAction.Notes = append(returnAction.Notes, note)
+			// Anything following @deprecated is kept as a note.
+			if note, noteErr := ParseLineString(line.Text); noteErr == nil {
+				returnAction.Notes = append(returnAction.Notes, note)
+			}
 		} else if lineType == "include" {
-			defRef, err = ParseLineString(line)
+			defRef, err = ParseLineString(line.Text)
 
-			if err != nil {
-				return returnAction, err
-			}
+			if err == nil {
+				if _, ok := definitions[defRef]; !ok {
+					diagnostics = diagnostics.Append(CodeMissingDefinition, LineError(line, CodeMissingDefinition, fmt.Errorf("Definition not found: %s", defRef)))
+					continue
+				}
 
-			if _, ok := definitions[defRef]; !ok {
-				return returnAction, fmt.Errorf("Definition not found: %s", defRef)
+				group = append(group[:i], group[i:]...)
+				group = append(group, definitions[defRef]...)
 			}
-
-			group = append(group[:i], group[i:]...)
-			group = append(group, definitions[defRef]...)
 		}
+
+		diagnostics = diagnostics.Append(CodeInvalidLine, LineError(line, CodeInvalidLine, err))
 	}
 
 	returnAction.Parameters, err = GenerateKeyValues("parameter", group, "")
-	returnAction.Returns, err = GenerateKeyValues("return", group, "")
+
+	diagnostics = diagnostics.Append(CodeInvalidLine, err)
+
+	returnAction.Returns, err = GenerateKeyValues("return", StatusLines(group, ""), "")
 
 	SortKeyValues(returnAction.Parameters)
 	SortKeyValues(returnAction.Returns)
 
-	if err != nil {
-		return returnAction, err
+	diagnostics = diagnostics.Append(CodeInvalidLine, err)
+
+	returnAction.Responses, err = GenerateResponses(group)
+
+	diagnostics = diagnostics.Append(CodeInvalidLine, err)
+
+	// Examples and locations can only be checked against values that parsed.
+	if len(diagnostics) > 0 {
+		return returnAction, diagnostics.Err()
+	}
+
+	returnLists := [][]KeyValue{returnAction.Parameters, returnAction.Returns}
+
+	for _, response := range returnAction.Responses {
+		returnLists = append(returnLists, response.Returns)
 	}
 
-	return returnAction, nil
+	diagnostics = diagnostics.Append(CodeInvalidExample, ApplyExamples(group, returnLists...))
+
+	diagnostics = diagnostics.Append(CodeInvalidLocation, ValidateLocationParameters(returnAction))
+
+	return returnAction, diagnostics.Err()
 }
 
-func GenerateKeyValues(keyValueType string, lines []string, objectspace string) ([]KeyValue, error) {
+func GenerateKeyValues(keyValueType string, lines []Line, objectspace string) ([]KeyValue, error) {
 	keyValues := make([]KeyValue, 0)
 
 	// Unpacking each line each iteration will be a bit more inefficient,
@@ -672,24 +1165,32 @@ func GenerateKeyValues(keyValueType string, lines []string, objectspace string)
 
 	var lineKeyValue KeyValue
 
+	// Check every line up front so that all of the bad ones are reported, rather
+	// than just the first one found while recursing.
+	if len(objectspace) == 0 {
+		if err := ValidateKeyValueLines(keyValueType, lines); err != nil {
+			return make([]KeyValue, 0), err
+		}
+	}
+
 	for _, line := range lines {
-		if !strings.Contains(line, startDefinition) &&
-			!strings.Contains(line, startAction) &&
-			!strings.Contains(line, startObject) &&
-			!strings.Contains(line, endDefinition) {
+		if !strings.Contains(line.Text, startDefinition) &&
+			!strings.Contains(line.Text, startAction) &&
+			!strings.Contains(line.Text, startObject) &&
+			!strings.Contains(line.Text, endDefinition) {
 
-			lineType, lineTypeError = ParseLineType(line)
+			lineType, lineTypeError = ParseLineType(line.Text)
 
 			if lineTypeError != nil {
-				return make([]KeyValue, 0), lineTypeError
+				return make([]KeyValue, 0), LineError(line, CodeInvalidLine, lineTypeError)
 			}
 
 			if lineType == keyValueType {
 
-				lineKeyValueType, lineKeyValueLimit, lineKeyValueFlag, lineKeyValueObjectspace, lineKeyValueDescription, lineKeyValueError = ParseLineKeyValue(line)
+				lineKeyValueType, lineKeyValueLimit, lineKeyValueFlag, lineKeyValueObjectspace, lineKeyValueDescription, lineKeyValueError = ParseLineKeyValue(line.Text)
 
 				if lineKeyValueError != nil {
-					return keyValues, lineKeyValueError
+					return keyValues, LineError(line, CodeInvalidLine, lineKeyValueError)
 				}
 
 				if strings.Contains(lineKeyValueObjectspace, objectspace) &&
@@ -701,6 +1202,10 @@ func GenerateKeyValues(keyValueType string, lines []string, objectspace string)
 						lineKeyValueLimit,
 						lineKeyValueDescription,
 						make([]KeyValue, 0),
+						nil,
+						ParseLineLocation(line.Text),
+						line.Source.Pointer(),
+						"",
 					}
 
 					lineKeyValue.Children, lineKeyValueError = GenerateKeyValues(keyValueType, lines, lineKeyValueObjectspace+".")
@@ -719,6 +1224,33 @@ func GenerateKeyValues(keyValueType string, lines []string, objectspace string)
 	return keyValues, nil
 }
 
+func ValidateKeyValueLines(keyValueType string, lines []Line) error {
+	var diagnostics Diagnostics
+
+	for _, line := range lines {
+		if isGroupMarker(line.Text) {
+			continue
+		}
+
+		lineType, err := ParseLineType(line.Text)
+
+		if err == nil && lineType == keyValueType {
+			_, _, _, _, _, err = ParseLineKeyValue(line.Text)
+		}
+
+		diagnostics = diagnostics.Append(CodeInvalidLine, LineError(line, CodeInvalidLine, err))
+	}
+
+	return diagnostics.Err()
+}
+
+func isGroupMarker(line string) bool {
+	return strings.Contains(line, startDefinition) ||
+		strings.Contains(line, startAction) ||
+		strings.Contains(line, startObject) ||
+		strings.Contains(line, endDefinition)
+}
+
 func SortKeyValues(keyValues []KeyValue) {
 	for i, _ := range keyValues {
 		SortKeyValues(keyValues[i].Children)
@@ -727,18 +1259,444 @@ func SortKeyValues(keyValues []KeyValue) {
 	sort.Stable(KeyValueByName(keyValues))
 }
 
-func GetDefinitionGroups(groups [][]string) (map[string][]string, error) {
-	definitionGroups := make(map[string][]string, 0)
+// Return the status codes of the responses of an action in order.
+func ResponseStatuses(action Action) []string {
+	statuses := make([]string, 0)
+
+	for status, _ := range action.Responses {
+		statuses = append(statuses, status)
+	}
+
+	sort.Strings(statuses)
+
+	return statuses
+}
+
+// Returns qualified with a status code aren't in the returns of an action, so
+// they're added from its responses - the first key/value with each name wins.
+func ResponseReturns(action Action) []KeyValue {
+	returns := make([]KeyValue, 0)
+	names := make(map[string]bool)
+
+	lists := [][]KeyValue{action.Returns}
+
+	for _, status := range ResponseStatuses(action) {
+		lists = append(lists, action.Responses[status].Returns)
+	}
+
+	for _, keyValues := range lists {
+		for _, keyValue := range keyValues {
+			if !names[keyValue.Name] {
+				names[keyValue.Name] = true
+				returns = append(returns, keyValue)
+			}
+		}
+	}
+
+	return returns
+}
+
+// Receive the lines of an action and a status code
+// Return the lines that aren't returns along with the returns qualified with
+// that code - a blank code selects the unqualified returns.
+func StatusLines(lines []Line, status string) []Line {
+	statusLines := make([]Line, 0)
+
+	for _, line := range lines {
+		if lineType, err := ParseLineType(line.Text); err == nil && lineType == "return" && ParseLineStatus(line.Text) != status {
+			continue
+		}
+
+		statusLines = append(statusLines, line)
+	}
+
+	return statusLines
+}
+
+// Builds a response for every status code used by an @status line or a
+// qualified return.  Unqualified returns are added to each of them according
+// to their flag - @success returns to 1xx, 2xx and 3xx codes, @failure returns
+// to 4xx and 5xx codes, and plain @return values to all of them.
+func GenerateResponses(lines []Line) (map[string]Response, error) {
+	var err error
+
+	descriptions := make(map[string]string)
+
+	for _, line := range lines {
+		lineType, err := ParseLineType(line.Text)
+
+		if err != nil {
+			return nil, LineError(line, CodeInvalidLine, err)
+		}
+
+		if lineType == "status" {
+			status, description, err := ParseLineStatusDescription(line.Text)
+
+			if err != nil {
+				return nil, LineError(line, CodeInvalidLine, err)
+			}
+
+			descriptions[status] = description
+		} else if lineType == "return" {
+			if status := ParseLineStatus(line.Text); len(status) > 0 {
+				if _, ok := descriptions[status]; !ok {
+					descriptions[status] = ""
+				}
+			}
+		}
+	}
+
+	if len(descriptions) == 0 {
+		return nil, nil
+	}
+
+	responses := make(map[string]Response)
+
+	for status, description := range descriptions {
+		statusFlag := "success"
+
+		if status[0:1] == "4" || status[0:1] == "5" {
+			statusFlag = "failure"
+		}
+
+		statusLines := make([]Line, 0)
+
+		for _, line := range lines {
+			lineType, _ := ParseLineType(line.Text)
+
+			if lineType != "return" {
+				continue
+			}
+
+			lineStatus := ParseLineStatus(line.Text)
+
+			if lineStatus == status {
+				statusLines = append(statusLines, line)
+			} else if len(lineStatus) == 0 {
+				_, _, flag, _, _, err := ParseLineKeyValue(line.Text)
+
+				if err != nil {
+					return nil, LineError(line, CodeInvalidLine, err)
+				}
+
+				if flag == "" || flag == statusFlag {
+					statusLines = append(statusLines, line)
+				}
+			}
+		}
+
+		response := Response{description, nil}
+
+		response.Returns, err = GenerateKeyValues("return", statusLines, "")
+
+		if err != nil {
+			return nil, err
+		}
+
+		SortKeyValues(response.Returns)
+
+		responses[status] = response
+	}
+
+	return responses, nil
+}
+
+var uriPlaceholder = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// Receive
+// /api/user/{userId}
+// Return [userId]
+func UriPlaceholders(uri string) []string {
+	placeholders := make([]string, 0)
+
+	for _, match := range uriPlaceholder.FindAllStringSubmatch(uri, -1) {
+		placeholders = append(placeholders, match[1])
+	}
+
+	return placeholders
+}
+
+// Every {name} placeholder in the uri of an action needs an @path parameter,
+// and every @path parameter needs a placeholder - matched regardless of case.
+// Path, query and header parameters have to be a Boolean, Integer, Decimal or
+// String.
+func ValidateLocationParameters(action Action) error {
+	var diagnostics Diagnostics
+
+	placeholders := make(map[string]bool)
+
+	for _, placeholder := range UriPlaceholders(action.Uri) {
+		placeholders[strings.ToLower(placeholder)] = true
+	}
+
+	pathParameters := make(map[string]bool)
+
+	for _, parameter := range action.Parameters {
+		diagnostics = diagnostics.Append(CodeInvalidLocation, validateNestedLocations(action, parameter.Name+".", parameter.Children))
+
+		if len(parameter.Location) == 0 {
+			continue
+		}
+
+		if _, ok := schemaTypes[parameter.Type]; !ok || parameter.Type == "object" || parameter.Type == "array" {
+			diagnostics = diagnostics.Append(CodeInvalidLocation, SourceError(parameter.Source, CodeInvalidLocation, fmt.Errorf("Invalid %s parameter: %s can't be of type %s in %s", parameter.Location, parameter.Name, parameter.Type, action.Ref)))
+		}
+
+		if parameter.Location == "path" {
+			if !placeholders[strings.ToLower(parameter.Name)] {
+				diagnostics = diagnostics.Append(CodeInvalidLocation, SourceError(parameter.Source, CodeInvalidLocation, fmt.Errorf("Invalid path parameter: {%s} not found in uri %s of %s", parameter.Name, action.Uri, action.Ref)))
+			}
+
+			pathParameters[strings.ToLower(parameter.Name)] = true
+		}
+	}
+
+	for _, placeholder := range UriPlaceholders(action.Uri) {
+		if !pathParameters[strings.ToLower(placeholder)] {
+			diagnostics = diagnostics.Append(CodeInvalidLocation, SourceError(action.Source, CodeInvalidLocation, fmt.Errorf("Missing path parameter: {%s} in uri %s of %s has no @path line", placeholder, action.Uri, action.Ref)))
+		}
+	}
+
+	return diagnostics.Err()
+}
+
+// Only top-level parameters can be sent apart from the body, so a location on
+// any parameter nested in another is an error.
+func validateNestedLocations(action Action, objectspace string, keyValues []KeyValue) error {
+	var diagnostics Diagnostics
+
+	for _, keyValue := range keyValues {
+		if len(keyValue.Location) > 0 {
+			diagnostics = diagnostics.Append(CodeInvalidLocation, SourceError(keyValue.Source, CodeInvalidLocation, fmt.Errorf("Invalid %s parameter: %s%s can't be nested in %s", keyValue.Location, objectspace, keyValue.Name, action.Ref)))
+		}
+
+		diagnostics = diagnostics.Append(CodeInvalidLocation, validateNestedLocations(action, objectspace+keyValue.Name+".", keyValue.Children))
+	}
+
+	return diagnostics.Err()
+}
+
+// Receive the parameters of an action and a location - blank for the body
+// Return the top-level parameters sent in that location.
+func LocationParameters(parameters []KeyValue, location string) []KeyValue {
+	keyValues := make([]KeyValue, 0)
+
+	for _, parameter := range parameters {
+		if parameter.Location == location {
+			keyValues = append(keyValues, parameter)
+		}
+	}
+
+	return keyValues
+}
+
+// Parameters flagged optional can be left out, as can query and header
+// parameters that aren't flagged at all, and returns flagged success or failure
+// are only sent in one case.
+func isOptional(keyValue KeyValue) bool {
+	if keyValue.Flag == "" && (keyValue.Location == "query" || keyValue.Location == "header") {
+		return true
+	}
+
+	return keyValue.Flag == "optional" || keyValue.Flag == "success" || keyValue.Flag == "failure"
+}
+
+// Attach the value of every @example line to the key/values with the same
+// object.space.  An example has to match at least one key/value, and has to be
+// valid for the type and limit of each one it matches.
+func ApplyExamples(lines []Line, keyValueLists ...[]KeyValue) error {
+	var diagnostics Diagnostics
+
+	for _, line := range lines {
+		if lineType, err := ParseLineType(line.Text); err != nil || lineType != "example" {
+			continue
+		}
+
+		objectspace, example, err := ParseLineExample(line.Text)
+
+		if err != nil {
+			diagnostics = diagnostics.Append(CodeInvalidExample, LineError(line, CodeInvalidExample, err))
+			continue
+		}
+
+		found := false
+
+		for _, keyValues := range keyValueLists {
+			if keyValue := FindKeyValue(keyValues, objectspace); keyValue != nil {
+				found = true
+
+				if err = ValidateExample(*keyValue, example); err != nil {
+					diagnostics = diagnostics.Append(CodeInvalidExample, LineError(line, CodeInvalidExample, fmt.Errorf("Invalid example for %s - %s\n\t%s", objectspace, err, line.Text)))
+					break
+				}
+
+				keyValue.Example, _ = json.Marshal(example)
+			}
+		}
+
+		if !found {
+			diagnostics = diagnostics.Append(CodeInvalidExample, LineError(line, CodeInvalidExample, fmt.Errorf("Invalid example - no value found for %s\n\t%s", objectspace, line.Text)))
+		}
+	}
+
+	return diagnostics.Err()
+}
+
+// Receive a list of key/values and an object.space such as user.id
+// Return the matching key/value, or nil if there isn't one.  Path, query and
+// header parameters keep the case they're declared with, so they match
+// regardless of case.
+func FindKeyValue(keyValues []KeyValue, objectspace string) *KeyValue {
+	parts := strings.SplitN(objectspace, ".", 2)
+
+	for i, _ := range keyValues {
+		if keyValues[i].Name != parts[0] && (len(keyValues[i].Location) == 0 || !strings.EqualFold(keyValues[i].Name, parts[0])) {
+			continue
+		}
+
+		if len(parts) == 1 {
+			return &keyValues[i]
+		}
+
+		return FindKeyValue(keyValues[i].Children, parts[1])
+	}
+
+	return nil
+}
+
+// Check that a decoded JSON value could be sent or returned for a key/value.
+// Any value can be null.
+func ValidateExample(keyValue KeyValue, example interface{}) error {
+	if example == nil {
+		return nil
+	}
+
+	if _, ok := TypeRef(keyValue.Type); ok {
+		if _, ok := example.(map[string]interface{}); !ok {
+			return fmt.Errorf("expected an object")
+		}
+
+		return nil
+	}
+
+	switch keyValue.Type {
+	case "boolean":
+		if _, ok := example.(bool); !ok {
+			return fmt.Errorf("expected a boolean")
+		}
+	case "integer":
+		number, ok := example.(json.Number)
+
+		if !ok {
+			return fmt.Errorf("expected an integer")
+		}
+
+		if _, err := number.Int64(); err != nil {
+			return fmt.Errorf("expected an integer")
+		}
+	case "decimal":
+		number, ok := example.(json.Number)
+
+		if !ok {
+			return fmt.Errorf("expected a decimal")
+		}
+
+		if keyValue.Limit > 0 {
+			if strings.ContainsAny(number.String(), "eE") {
+				return fmt.Errorf("expected at most %d decimal places", keyValue.Limit)
+			}
+
+			if dotIndex := strings.Index(number.String(), "."); dotIndex >= 0 &&
+				int64(len(number.String())-dotIndex-1) > keyValue.Limit {
+				return fmt.Errorf("expected at most %d decimal places", keyValue.Limit)
+			}
+		}
+	case "string":
+		value, ok := example.(string)
+
+		if !ok {
+			return fmt.Errorf("expected a string")
+		}
+
+		if keyValue.Limit > 0 && int64(utf8.RuneCountInString(value)) > keyValue.Limit {
+			return fmt.Errorf("expected at most %d characters", keyValue.Limit)
+		}
+	case "object":
+		value, ok := example.(map[string]interface{})
+
+		if !ok {
+			return fmt.Errorf("expected an object")
+		}
+
+		// An object without children can hold anything.
+		if len(keyValue.Children) == 0 {
+			return nil
+		}
+
+		return validateExampleChildren(keyValue, value)
+	case "array":
+		values, ok := example.([]interface{})
+
+		if !ok {
+			return fmt.Errorf("expected an array")
+		}
+
+		if keyValue.Limit > 0 && int64(len(values)) > keyValue.Limit {
+			return fmt.Errorf("expected at most %d elements", keyValue.Limit)
+		}
+
+		// The elements of an array are only known when it has children.
+		if len(keyValue.Children) == 0 {
+			return nil
+		}
+
+		for _, element := range values {
+			value, ok := element.(map[string]interface{})
+
+			if !ok {
+				return fmt.Errorf("expected an array of objects")
+			}
+
+			if err := validateExampleChildren(keyValue, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateExampleChildren(keyValue KeyValue, value map[string]interface{}) error {
+	for key, childExample := range value {
+		child := FindKeyValue(keyValue.Children, key)
+
+		if child == nil {
+			return fmt.Errorf("%s is not a declared value", key)
+		}
+
+		if err := ValidateExample(*child, childExample); err != nil {
+			return fmt.Errorf("%s: %s", key, err)
+		}
+	}
+
+	return nil
+}
+
+func GetDefinitionGroups(groups [][]Line) (map[string][]Line, error) {
+	definitionGroups := make(map[string][]Line, 0)
+
+	var diagnostics Diagnostics
 
 	for _, group := range groups {
-		if groupType, err := ParseGroupType(group[0]); err != nil {
-			return definitionGroups, err
+		if groupType, err := ParseGroupType(group[0].Text); err != nil {
+			diagnostics = diagnostics.Append(CodeInvalidGroup, LineError(group[0], CodeInvalidGroup, err))
 		} else {
 			if groupType == "definition" {
+				start := group[0]
 				group = group[1:]
 				group = group[0 : len(group)-1]
 				if groupRef, err := ParseGroupRef(group); err != nil {
-					return definitionGroups, err
+					diagnostics = diagnostics.Append(CodeMissingRef, LineError(start, CodeMissingRef, err))
 				} else {
 					definitionGroups[groupRef] = group
 				}
@@ -750,27 +1708,30 @@ func GetDefinitionGroups(groups [][]string) (map[string][]string, error) {
 	// in the other definitions.
 	for i, definitionGroup := range definitionGroups {
 		for j, line := range definitionGroup {
-			if lineType, err := ParseLineType(line); err == nil && lineType == "ref" {
+			if lineType, err := ParseLineType(line.Text); err == nil && lineType == "ref" {
 				definitionGroups[i] = append(definitionGroups[i][:j], definitionGroups[i][(j+1):]...)
 			}
 		}
 	}
 
-	return definitionGroups, nil
+	return definitionGroups, diagnostics.Err()
 }
 
-func GetObjectGroups(groups [][]string) (map[string][]string, error) {
-	objectGroups := make(map[string][]string, 0)
+func GetObjectGroups(groups [][]Line) (map[string][]Line, error) {
+	objectGroups := make(map[string][]Line, 0)
+
+	var diagnostics Diagnostics
 
 	for _, group := range groups {
-		if groupType, err := ParseGroupType(group[0]); err != nil {
-			return objectGroups, err
+		if groupType, err := ParseGroupType(group[0].Text); err != nil {
+			diagnostics = diagnostics.Append(CodeInvalidGroup, LineError(group[0], CodeInvalidGroup, err))
 		} else {
 			if groupType == "object" {
+				start := group[0]
 				group = group[1:]
 				group = group[0 : len(group)-1]
 				if groupRef, err := ParseGroupRef(group); err != nil {
-					return objectGroups, err
+					diagnostics = diagnostics.Append(CodeMissingRef, LineError(start, CodeMissingRef, err))
 				} else {
 					objectGroups[groupRef] = group
 				}
@@ -778,21 +1739,24 @@ func GetObjectGroups(groups [][]string) (map[string][]string, error) {
 		}
 	}
 
-	return objectGroups, nil
+	return objectGroups, diagnostics.Err()
 }
 
-func GetActionGroups(groups [][]string) (map[string][]string, error) {
-	actionGroups := make(map[string][]string, 0)
+func GetActionGroups(groups [][]Line) (map[string][]Line, error) {
+	actionGroups := make(map[string][]Line, 0)
+
+	var diagnostics Diagnostics
 
 	for _, group := range groups {
-		if groupType, err := ParseGroupType(group[0]); err != nil {
-			return actionGroups, err
+		if groupType, err := ParseGroupType(group[0].Text); err != nil {
+			diagnostics = diagnostics.Append(CodeInvalidGroup, LineError(group[0], CodeInvalidGroup, err))
 		} else {
 			if groupType == "action" {
+				start := group[0]
 				group = group[1:]
 				group = group[0 : len(group)-1]
 				if groupRef, err := ParseGroupRef(group); err != nil {
-					return actionGroups, err
+					diagnostics = diagnostics.Append(CodeMissingRef, LineError(start, CodeMissingRef, err))
 				} else {
 					actionGroups[groupRef] = group
 				}
@@ -800,5 +1764,5 @@ func GetActionGroups(groups [][]string) (map[string][]string, error) {
 		}
 	}
 
-	return actionGroups, nil
+	return actionGroups, diagnostics.Err()
 }
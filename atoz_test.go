@@ -1,9 +1,11 @@
-package main
+package atoz
 
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -74,6 +76,76 @@ var testParseLineTypeCases = []testParseLineTypeCase{
 		"return",
 		false,
 	},
+	{
+		"@example Objectspace Value",
+		"example",
+		false,
+	},
+	{
+		"@method GET",
+		"method",
+		false,
+	},
+	{
+		"@path {Type,Limit} Objectspace Description",
+		"parameter",
+		false,
+	},
+	{
+		"@query {Type,Limit} Objectspace Description",
+		"parameter",
+		false,
+	},
+	{
+		"@header {Type,Limit} Objectspace Description",
+		"parameter",
+		false,
+	},
+	{
+		"@query:optional {Type,Limit} Objectspace Description",
+		"parameter",
+		false,
+	},
+	{
+		"@header:required {Type,Limit} Objectspace Description",
+		"parameter",
+		false,
+	},
+	{
+		"@header:404 {Type,Limit} Objectspace Description",
+		"",
+		true,
+	},
+	{
+		"@path:optional {Type,Limit} Objectspace Description",
+		"",
+		true,
+	},
+	{
+		"@status 404 Not found.",
+		"status",
+		false,
+	},
+	{
+		"@return:404 {Type,Limit} Objectspace Description",
+		"return",
+		false,
+	},
+	{
+		"@failure:409 {Type,Limit} Objectspace Description",
+		"return",
+		false,
+	},
+	{
+		"@return:abc {Type,Limit} Objectspace Description",
+		"",
+		true,
+	},
+	{
+		"@parameter:404 {Type,Limit} Objectspace Description",
+		"",
+		true,
+	},
 }
 
 func TestParseLineType(t *testing.T) {
@@ -134,7 +206,7 @@ func TestParseGroupRef(t *testing.T) {
 	var resultErr error
 
 	for _, test := range testParseGroupRefCases {
-		resultLineRefValue, resultErr = ParseGroupRef(test.group)
+		resultLineRefValue, resultErr = ParseGroupRef(NewLines(test.group))
 
 		if resultErr != nil {
 			if !test.err {
@@ -405,6 +477,46 @@ var testParseLineKeyValueCases = []testParseLineKeyValueCase{
 		"This is an object.",
 		false,
 	},
+	// Path - always required
+	{
+		"@path {Integer} id The ID of the user.",
+		"integer",
+		"required",
+		-1,
+		"id",
+		"The ID of the user.",
+		false,
+	},
+	// Query - optional unless qualified
+	{
+		"@query {String} fields The fields to return.",
+		"string",
+		"",
+		0,
+		"fields",
+		"The fields to return.",
+		false,
+	},
+	// Header - qualified as required
+	{
+		"@header:required {String,64} x-auth-token The token.",
+		"string",
+		"required",
+		64,
+		"x-auth-token",
+		"The token.",
+		false,
+	},
+	// Query - qualified as optional
+	{
+		"@query:optional {Integer} page The page.",
+		"integer",
+		"optional",
+		-1,
+		"page",
+		"The page.",
+		false,
+	},
 }
 
 func TestParseLineKeyValue(t *testing.T) {
@@ -647,18 +759,38 @@ int main(void)
 	},
 }
 
+func lineGroups(groups map[string][]string) map[string][]Line {
+	result := make(map[string][]Line)
+
+	for ref, group := range groups {
+		result[ref] = NewLines(group)
+	}
+
+	return result
+}
+
+func lineGroupTexts(groups map[string][]Line) map[string][]string {
+	result := make(map[string][]string)
+
+	for ref, group := range groups {
+		result[ref] = LineTexts(group)
+	}
+
+	return result
+}
+
 func TestParseGroups(t *testing.T) {
-	var resultLineGroups [][]string
+	var resultLineGroups [][]Line
 	var resultErr error
 
-	var resultLineDefinitionGroups map[string][]string
-	var resultLineActionGroups map[string][]string
-	var resultLineObjectGroups map[string][]string
+	var resultLineDefinitionGroups map[string][]Line
+	var resultLineActionGroups map[string][]Line
+	var resultLineObjectGroups map[string][]Line
 
 	for _, test := range testParseGroupsCases {
 		buffer := bytes.NewBufferString(test.lines)
 		reader := bufio.NewReader(buffer)
-		resultLineGroups, resultErr = ParseGroups(reader)
+		resultLineGroups, resultErr = ParseGroups(reader, "")
 
 		if resultErr != nil {
 			if !test.err {
@@ -667,7 +799,7 @@ func TestParseGroups(t *testing.T) {
 			}
 		} else {
 			for i, _ := range test.groups {
-				if !reflect.DeepEqual(test.groups[i], resultLineGroups[i]) {
+				if !reflect.DeepEqual(test.groups[i], LineTexts(resultLineGroups[i])) {
 					t.Errorf("TestParseGroups Groups Mismatch:")
 					t.Errorf("Expected:")
 					for _, line := range test.groups[i] {
@@ -675,7 +807,7 @@ func TestParseGroups(t *testing.T) {
 					}
 					t.Errorf("Actual:")
 					for _, line := range resultLineGroups[i] {
-						t.Errorf("\t%s", line)
+						t.Errorf("\t%s", line.Text)
 					}
 				}
 			}
@@ -687,7 +819,7 @@ func TestParseGroups(t *testing.T) {
 				return
 			}
 
-			if !reflect.DeepEqual(resultLineDefinitionGroups, test.definitionGroups) {
+			if !reflect.DeepEqual(lineGroupTexts(resultLineDefinitionGroups), test.definitionGroups) {
 				t.Errorf("TestParseGroups GetDefinitionGroups Mismatch:")
 				t.Errorf("Expected:")
 				for _, group := range test.definitionGroups {
@@ -698,7 +830,7 @@ func TestParseGroups(t *testing.T) {
 				t.Errorf("Actual:")
 				for _, group := range resultLineDefinitionGroups {
 					for _, line := range group {
-						t.Errorf("\t%s", line)
+						t.Errorf("\t%s", line.Text)
 					}
 				}
 			}
@@ -710,7 +842,7 @@ func TestParseGroups(t *testing.T) {
 				return
 			}
 
-			if !reflect.DeepEqual(resultLineActionGroups, test.actionGroups) {
+			if !reflect.DeepEqual(lineGroupTexts(resultLineActionGroups), test.actionGroups) {
 				t.Errorf("TestParseGroups GetActionGroups Mismatch:")
 				t.Errorf("Expected:")
 				for _, group := range test.actionGroups {
@@ -721,7 +853,7 @@ func TestParseGroups(t *testing.T) {
 				t.Errorf("Actual:")
 				for _, group := range resultLineActionGroups {
 					for _, line := range group {
-						t.Errorf("\t%s", line)
+						t.Errorf("\t%s", line.Text)
 					}
 				}
 			}
@@ -733,7 +865,7 @@ func TestParseGroups(t *testing.T) {
 				return
 			}
 
-			if !reflect.DeepEqual(resultLineDefinitionGroups, test.definitionGroups) {
+			if !reflect.DeepEqual(lineGroupTexts(resultLineDefinitionGroups), test.definitionGroups) {
 				t.Errorf("TestParseGroups GetObjectGroups Mismatch:")
 				t.Errorf("Expected:")
 				for _, group := range test.objectGroups {
@@ -744,7 +876,7 @@ func TestParseGroups(t *testing.T) {
 				t.Errorf("Actual:")
 				for _, group := range resultLineObjectGroups {
 					for _, line := range group {
-						t.Errorf("\t%s", line)
+						t.Errorf("\t%s", line.Text)
 					}
 				}
 			}
@@ -833,6 +965,10 @@ var testGenerateKeyValuesCases = []testGenerateKeyValuesCase{
 					0,
 					"Email address for the user.",
 					[]KeyValue{},
+					nil,
+					"",
+					nil,
+					"",
 				},
 				{
 					"id",
@@ -841,6 +977,10 @@ var testGenerateKeyValuesCases = []testGenerateKeyValuesCase{
 					-1,
 					"Unique ID of the user.",
 					[]KeyValue{},
+					nil,
+					"",
+					nil,
+					"",
 				},
 				{
 					"name",
@@ -849,6 +989,10 @@ var testGenerateKeyValuesCases = []testGenerateKeyValuesCase{
 					0,
 					"Name of the user.",
 					[]KeyValue{},
+					nil,
+					"",
+					nil,
+					"",
 				},
 			},
 		},
@@ -886,6 +1030,10 @@ var testGenerateKeyValuesCases = []testGenerateKeyValuesCase{
 							0,
 							"Email address for the user.",
 							[]KeyValue{},
+							nil,
+							"",
+							nil,
+							"",
 						},
 						KeyValue{
 							"id",
@@ -894,6 +1042,10 @@ var testGenerateKeyValuesCases = []testGenerateKeyValuesCase{
 							-1,
 							"Unique ID of the user.",
 							[]KeyValue{},
+							nil,
+							"",
+							nil,
+							"",
 						},
 						KeyValue{
 							"name",
@@ -902,6 +1054,10 @@ var testGenerateKeyValuesCases = []testGenerateKeyValuesCase{
 							0,
 							"Name of the user.",
 							[]KeyValue{},
+							nil,
+							"",
+							nil,
+							"",
 						},
 						KeyValue{
 							"role",
@@ -910,8 +1066,16 @@ var testGenerateKeyValuesCases = []testGenerateKeyValuesCase{
 							0,
 							"The role of the user.",
 							[]KeyValue{},
+							nil,
+							"",
+							nil,
+							"",
 						},
 					},
+					nil,
+					"",
+					nil,
+					"",
 				},
 			},
 		},
@@ -957,6 +1121,10 @@ var testGenerateKeyValuesCases = []testGenerateKeyValuesCase{
 									0,
 									"Token key.",
 									[]KeyValue{},
+									nil,
+									"",
+									nil,
+									"",
 								},
 								KeyValue{
 									"secret",
@@ -965,8 +1133,16 @@ var testGenerateKeyValuesCases = []testGenerateKeyValuesCase{
 									0,
 									"Token secret.",
 									[]KeyValue{},
+									nil,
+									"",
+									nil,
+									"",
 								},
 							},
+							nil,
+							"",
+							nil,
+							"",
 						},
 						KeyValue{
 							"user",
@@ -982,6 +1158,10 @@ var testGenerateKeyValuesCases = []testGenerateKeyValuesCase{
 									0,
 									"Email address.",
 									[]KeyValue{},
+									nil,
+									"",
+									nil,
+									"",
 								},
 								KeyValue{
 									"id",
@@ -990,6 +1170,10 @@ var testGenerateKeyValuesCases = []testGenerateKeyValuesCase{
 									-1,
 									"User ID.",
 									[]KeyValue{},
+									nil,
+									"",
+									nil,
+									"",
 								},
 								KeyValue{
 									"name",
@@ -998,10 +1182,22 @@ var testGenerateKeyValuesCases = []testGenerateKeyValuesCase{
 									0,
 									"First and last ( or common ) name.",
 									[]KeyValue{},
+									nil,
+									"",
+									nil,
+									"",
 								},
 							},
+							nil,
+							"",
+							nil,
+							"",
 						},
 					},
+					nil,
+					"",
+					nil,
+					"",
 				},
 			},
 		},
@@ -1030,6 +1226,10 @@ var testGenerateKeyValuesCases = []testGenerateKeyValuesCase{
 					-1,
 					"Unique ID of the user.",
 					[]KeyValue{},
+					nil,
+					"",
+					nil,
+					"",
 				},
 				{
 					"name",
@@ -1038,6 +1238,10 @@ var testGenerateKeyValuesCases = []testGenerateKeyValuesCase{
 					0,
 					"Name of the user.",
 					[]KeyValue{},
+					nil,
+					"",
+					nil,
+					"",
 				},
 			},
 		},
@@ -1047,14 +1251,14 @@ var testGenerateKeyValuesCases = []testGenerateKeyValuesCase{
 
 func TestGenerateKeyValues(t *testing.T) {
 	var resultKeyValues []KeyValue
-	var resultGroups [][]string
+	var resultGroups [][]Line
 	var resultErr error
 
 	for _, test := range testGenerateKeyValuesCases {
 
 		buffer := bytes.NewBufferString(test.definition)
 		reader := bufio.NewReader(buffer)
-		resultGroups, resultErr = ParseGroups(reader)
+		resultGroups, resultErr = ParseGroups(reader, "")
 
 		if resultErr != nil {
 			t.Errorf("Unexpected Error: Error parsing group definitions: %s", resultErr)
@@ -1112,6 +1316,7 @@ var testGenerateActionCases = []testGenerateActionCase{
 		Action{
 			"User Lookup",
 			"/MyApp/User/Lookup",
+			"",
 			"/User/Lookup",
 			"Get the information for a user.",
 			[]string{
@@ -1133,6 +1338,10 @@ var testGenerateActionCases = []testGenerateActionCase{
 							-1,
 							"Auth ID.",
 							[]KeyValue{},
+							nil,
+							"",
+							nil,
+							"",
 						},
 						KeyValue{
 							"key",
@@ -1141,8 +1350,16 @@ var testGenerateActionCases = []testGenerateActionCase{
 							64,
 							"Auth Key.",
 							[]KeyValue{},
+							nil,
+							"",
+							nil,
+							"",
 						},
 					},
+					nil,
+					"",
+					nil,
+					"",
 				},
 				KeyValue{
 					"id",
@@ -1151,6 +1368,10 @@ var testGenerateActionCases = []testGenerateActionCase{
 					-1,
 					"The ID of the user.",
 					[]KeyValue{},
+					nil,
+					"",
+					nil,
+					"",
 				},
 			},
 			[]KeyValue{
@@ -1161,6 +1382,10 @@ var testGenerateActionCases = []testGenerateActionCase{
 					0,
 					"An error message describing what went wrong.",
 					[]KeyValue{},
+					nil,
+					"",
+					nil,
+					"",
 				},
 				KeyValue{
 					"success",
@@ -1169,6 +1394,10 @@ var testGenerateActionCases = []testGenerateActionCase{
 					-1,
 					"A boolean to show whether or not the request was successful.",
 					[]KeyValue{},
+					nil,
+					"",
+					nil,
+					"",
 				},
 				KeyValue{
 					"user",
@@ -1177,8 +1406,17 @@ var testGenerateActionCases = []testGenerateActionCase{
 					-1,
 					"The user.",
 					[]KeyValue{},
+					nil,
+					"",
+					nil,
+					"",
 				},
 			},
+			nil,
+			nil,
+			nil,
+			nil,
+			false,
 		},
 		false,
 	},
@@ -1189,7 +1427,7 @@ func TestGenerateAction(t *testing.T) {
 	var resultErr error
 
 	for _, test := range testGenerateActionCases {
-		resultAction, resultErr = GenerateAction(test.group, test.definitions)
+		resultAction, resultErr = GenerateAction(NewLines(test.group), lineGroups(test.definitions))
 
 		if resultErr != nil {
 			if !test.err {
@@ -1241,6 +1479,10 @@ var testGenerateObjectCases = []testGenerateObjectCase{
 					254,
 					"",
 					[]KeyValue{},
+					nil,
+					"",
+					nil,
+					"",
 				},
 				KeyValue{
 					"id",
@@ -1249,6 +1491,10 @@ var testGenerateObjectCases = []testGenerateObjectCase{
 					-1,
 					"",
 					[]KeyValue{},
+					nil,
+					"",
+					nil,
+					"",
 				},
 				KeyValue{
 					"name",
@@ -1257,6 +1503,10 @@ var testGenerateObjectCases = []testGenerateObjectCase{
 					0,
 					"",
 					[]KeyValue{},
+					nil,
+					"",
+					nil,
+					"",
 				},
 				KeyValue{
 					"role",
@@ -1265,8 +1515,14 @@ var testGenerateObjectCases = []testGenerateObjectCase{
 					0,
 					"The primary role of the user.",
 					[]KeyValue{},
+					nil,
+					"",
+					nil,
+					"",
 				},
 			},
+			nil,
+			false,
 		},
 		false,
 	},
@@ -1277,7 +1533,7 @@ func TestGenerateObject(t *testing.T) {
 	var resultErr error
 
 	for _, test := range testGenerateObjectCases {
-		resultObject, resultErr = GenerateObject(test.group, test.definitions)
+		resultObject, resultErr = GenerateObject(NewLines(test.group), lineGroups(test.definitions))
 
 		if resultErr != nil {
 			if !test.err {
@@ -1293,3 +1549,614 @@ func TestGenerateObject(t *testing.T) {
 		}
 	}
 }
+
+type testParseLineExampleCase struct {
+	line        string
+	objectspace string
+	example     interface{}
+	err         bool
+}
+
+var testParseLineExampleCases = []testParseLineExampleCase{
+	{
+		" * @example user.id 12",
+		"user.id",
+		json.Number("12"),
+		false,
+	},
+	{
+		" * @example User.Role \"admin user\"",
+		"user.role",
+		"admin user",
+		false,
+	},
+	{
+		" * @example tags [\"a\", \"b\"]",
+		"tags",
+		[]interface{}{"a", "b"},
+		false,
+	},
+	{
+		" * @example user.id",
+		"",
+		nil,
+		true,
+	},
+	{
+		" * @example user.role admin",
+		"",
+		nil,
+		true,
+	},
+	{
+		" * @example user.id 1 2",
+		"",
+		nil,
+		true,
+	},
+	{
+		" * @example user.id 12 }",
+		"",
+		nil,
+		true,
+	},
+	{
+		" * @example user.id 12 ",
+		"user.id",
+		json.Number("12"),
+		false,
+	},
+}
+
+func TestParseLineExample(t *testing.T) {
+	var resultObjectspace string
+	var resultExample interface{}
+	var resultErr error
+
+	for _, test := range testParseLineExampleCases {
+		resultObjectspace, resultExample, resultErr = ParseLineExample(test.line)
+
+		if resultErr != nil {
+			if !test.err {
+				t.Errorf("TestParseLineExample Unexpected error: %s", resultErr)
+				return
+			}
+		} else {
+			if test.err {
+				t.Errorf("TestParseLineExample - Should have errored out: %s", test.line)
+				return
+			}
+			if resultObjectspace != test.objectspace || !reflect.DeepEqual(resultExample, test.example) {
+				t.Errorf("TestParseLineExample Mismatch: %s\nExpected: %s %v\n  Actual: %s %v", test.line, test.objectspace, test.example, resultObjectspace, resultExample)
+				return
+			}
+		}
+	}
+}
+
+type testValidateExampleCase struct {
+	keyValue KeyValue
+	example  string
+	err      bool
+}
+
+var testValidateExampleCases = []testValidateExampleCase{
+	{KeyValue{"id", "", "integer", -1, "", []KeyValue{}, nil, "", nil, ""}, "12", false},
+	{KeyValue{"id", "", "integer", -1, "", []KeyValue{}, nil, "", nil, ""}, "1.5", true},
+	{KeyValue{"id", "", "integer", -1, "", []KeyValue{}, nil, "", nil, ""}, "\"12\"", true},
+	{KeyValue{"price", "", "decimal", 2, "", []KeyValue{}, nil, "", nil, ""}, "1.25", false},
+	{KeyValue{"price", "", "decimal", 2, "", []KeyValue{}, nil, "", nil, ""}, "1.255", true},
+	{KeyValue{"code", "", "string", 2, "", []KeyValue{}, nil, "", nil, ""}, "\"us\"", false},
+	{KeyValue{"code", "", "string", 2, "", []KeyValue{}, nil, "", nil, ""}, "\"usa\"", true},
+	{KeyValue{"enabled", "", "boolean", -1, "", []KeyValue{}, nil, "", nil, ""}, "true", false},
+	{
+		KeyValue{"users", "", "array", 1, "", []KeyValue{
+			KeyValue{"id", "", "integer", -1, "", []KeyValue{}, nil, "", nil, ""},
+		}, nil, "", nil, ""},
+		"[{\"id\": 1}]",
+		false,
+	},
+	{
+		KeyValue{"users", "", "array", 1, "", []KeyValue{
+			KeyValue{"id", "", "integer", -1, "", []KeyValue{}, nil, "", nil, ""},
+		}, nil, "", nil, ""},
+		"[{\"id\": 1}, {\"id\": 2}]",
+		true,
+	},
+	{
+		KeyValue{"user", "", "object", -1, "", []KeyValue{
+			KeyValue{"id", "", "integer", -1, "", []KeyValue{}, nil, "", nil, ""},
+		}, nil, "", nil, ""},
+		"{\"name\": \"Bob\"}",
+		true,
+	},
+	{KeyValue{"user", "", "#/Application/User#", -1, "", []KeyValue{}, nil, "", nil, ""}, "{\"id\": 1}", false},
+	{KeyValue{"tags", "optional", "array", 0, "", []KeyValue{}, nil, "", nil, ""}, "[\"a\", \"b\"]", false},
+	{KeyValue{"tags", "optional", "array", 1, "", []KeyValue{}, nil, "", nil, ""}, "[\"a\", \"b\"]", true},
+	{KeyValue{"settings", "optional", "object", -1, "", []KeyValue{}, nil, "", nil, ""}, "{\"theme\": \"dark\"}", false},
+}
+
+func TestValidateExample(t *testing.T) {
+	for _, test := range testValidateExampleCases {
+		_, example, err := ParseLineExample("@example " + test.keyValue.Name + " " + test.example)
+
+		if err != nil {
+			t.Errorf("TestValidateExample Unexpected error: %s", err)
+			return
+		}
+
+		err = ValidateExample(test.keyValue, example)
+
+		if err != nil && !test.err {
+			t.Errorf("TestValidateExample Unexpected error: %s %s", test.example, err)
+		} else if err == nil && test.err {
+			t.Errorf("TestValidateExample - Should have errored out: %s", test.example)
+		}
+	}
+}
+
+func TestApplyExamples(t *testing.T) {
+	group := []string{
+		" * @name User",
+		" * @ref /Application/User",
+		" * @property {Object} settings",
+		" * @property {String,8} settings.theme",
+		" * @example settings.theme \"dark\"",
+	}
+
+	object, err := GenerateObject(NewLines(group), map[string][]Line{})
+
+	if err != nil {
+		t.Errorf("TestApplyExamples Unexpected error: %s", err)
+		return
+	}
+
+	if string(object.Properties[0].Children[0].Example) != `"dark"` {
+		t.Errorf("TestApplyExamples Example not attached: %v", object.Properties[0].Children[0].Example)
+	}
+
+	object, err = GenerateObject(NewLines(append(group[:4:4], " * @example settings.theme null")), map[string][]Line{})
+
+	if err != nil || string(object.Properties[0].Children[0].Example) != "null" {
+		t.Errorf("TestApplyExamples Null example not attached: %v %v", object.Properties[0].Children[0].Example, err)
+	}
+
+	if example := ExampleKeyValues(object.Properties, map[string]Object{}); !reflect.DeepEqual(example, map[string]interface{}{"settings": map[string]interface{}{"theme": nil}}) {
+		t.Errorf("TestApplyExamples Null example not used: %v", example)
+	}
+
+	for _, line := range []string{" * @example settings.theme \"much too long\"", " * @example settings.font \"serif\""} {
+		_, err = GenerateObject(NewLines(append(group[:4:4], line)), map[string][]Line{})
+
+		if err == nil {
+			t.Errorf("TestApplyExamples - Should have errored out: %s", line)
+		}
+	}
+}
+
+type testParseLineUriCase struct {
+	line   string
+	method string
+	uri    string
+	err    bool
+}
+
+var testParseLineUriCases = []testParseLineUriCase{
+	{
+		" * @uri /api/user",
+		"",
+		"/api/user",
+		false,
+	},
+	{
+		" * @uri GET /api/user",
+		"GET",
+		"/api/user",
+		false,
+	},
+	{
+		" * @uri delete /api/user",
+		"DELETE",
+		"/api/user",
+		false,
+	},
+	{
+		" * @uri GET",
+		"",
+		"",
+		true,
+	},
+	{
+		" * @uri ",
+		"",
+		"",
+		true,
+	},
+}
+
+func TestParseLineUri(t *testing.T) {
+	var resultMethod string
+	var resultUri string
+	var resultErr error
+
+	for _, test := range testParseLineUriCases {
+		resultMethod, resultUri, resultErr = ParseLineUri(test.line)
+
+		if resultErr != nil {
+			if !test.err {
+				t.Errorf("TestParseLineUri Unexpected error: %s", resultErr)
+				return
+			}
+		} else {
+			if test.err {
+				t.Errorf("TestParseLineUri - Should have errored out: %s", test.line)
+				return
+			}
+			if resultMethod != test.method || resultUri != test.uri {
+				t.Errorf("TestParseLineUri Mismatch: %s\nExpected: %s %s\n  Actual: %s %s", test.line, test.method, test.uri, resultMethod, resultUri)
+				return
+			}
+		}
+	}
+}
+
+func TestGenerateActionMethod(t *testing.T) {
+	cases := map[string][]string{
+		"POST":   []string{" * @ref /User/Get", " * @uri /api/user"},
+		"GET":    []string{" * @ref /User/Get", " * @uri GET /api/user"},
+		"DELETE": []string{" * @ref /User/Get", " * @method delete", " * @uri /api/user"},
+	}
+
+	for method, group := range cases {
+		action, err := GenerateAction(NewLines(group), map[string][]Line{})
+
+		if err != nil {
+			t.Errorf("TestGenerateActionMethod Unexpected error: %s", err)
+			return
+		}
+
+		if ActionMethod(action) != method || action.Uri != "/api/user" {
+			t.Errorf("TestGenerateActionMethod Mismatch\nExpected: %s /api/user\n  Actual: %s %s", method, action.Method, action.Uri)
+		}
+	}
+
+	if action, _ := GenerateAction(NewLines(cases["POST"]), map[string][]Line{}); len(action.Method) > 0 {
+		t.Errorf("TestGenerateActionMethod Method should be left blank when not declared: %s", action.Method)
+	}
+
+	for _, group := range [][]string{
+		[]string{" * @ref /User/Get", " * @method PUT", " * @uri GET /api/user"},
+		[]string{" * @ref /User/Get", " * @method FETCH", " * @uri /api/user"},
+	} {
+		if _, err := GenerateAction(NewLines(group), map[string][]Line{}); err == nil {
+			t.Errorf("TestGenerateActionMethod - Should have errored out: %s", group)
+		}
+	}
+}
+
+func TestCheckDuplicateRoutes(t *testing.T) {
+	actions := []Action{
+		Action{Ref: "/User/Get", Method: "GET", Uri: "/api/user"},
+		Action{Ref: "/User/Update", Method: "PUT", Uri: "/api/user"},
+	}
+
+	if err := CheckDuplicateRoutes(actions); err != nil {
+		t.Errorf("TestCheckDuplicateRoutes Unexpected error: %s", err)
+	}
+
+	actions = append(actions, Action{Ref: "/User/Fetch", Method: "GET", Uri: "/api/user"})
+
+	if err := CheckDuplicateRoutes(actions); err == nil {
+		t.Errorf("TestCheckDuplicateRoutes - Should have errored out.")
+	}
+
+	actions = []Action{
+		Action{Ref: "/User/Get", Method: "GET", Uri: "/api/user/{id}"},
+		Action{Ref: "/User/Fetch", Method: "GET", Uri: "/api/user/{userId}"},
+	}
+
+	if err := CheckDuplicateRoutes(actions); err == nil {
+		t.Errorf("TestCheckDuplicateRoutes - Should have errored out on placeholders with different names.")
+	}
+}
+
+type testValidateLocationParametersCase struct {
+	group []string
+	err   bool
+}
+
+var testValidateLocationParametersCases = []testValidateLocationParametersCase{
+	{
+		[]string{
+			" * @ref /User/Get",
+			" * @uri GET /api/user/{userId}",
+			" * @path {Integer} userId",
+			" * @query {String} fields",
+			" * @header {String} x-token",
+		},
+		false,
+	},
+	{
+		[]string{
+			" * @ref /User/Get",
+			" * @uri GET /api/user/{userId}",
+			" * @query {Integer} userId",
+		},
+		true,
+	},
+	{
+		[]string{
+			" * @ref /User/Get",
+			" * @uri GET /api/user",
+			" * @path {Integer} userId",
+		},
+		true,
+	},
+	{
+		[]string{
+			" * @ref /User/Get",
+			" * @uri GET /api/user",
+			" * @query {Object} filter",
+		},
+		true,
+	},
+	{
+		[]string{
+			" * @ref /User/Get",
+			" * @uri GET /api/user",
+			" * @parameter {Object} auth",
+			" * @header {String} auth.token",
+		},
+		true,
+	},
+}
+
+func TestValidateLocationParameters(t *testing.T) {
+	for _, test := range testValidateLocationParametersCases {
+		action, err := GenerateAction(NewLines(test.group), map[string][]Line{})
+
+		if err != nil {
+			if !test.err {
+				t.Errorf("TestValidateLocationParameters Unexpected error: %s", err)
+			}
+			continue
+		}
+
+		if test.err {
+			t.Errorf("TestValidateLocationParameters - Should have errored out: %s", test.group)
+			continue
+		}
+
+		for _, parameter := range action.Parameters {
+			if parameter.Location == "path" && parameter.Flag != "required" {
+				t.Errorf("TestValidateLocationParameters Path parameters should be required: %s", parameter.Name)
+			}
+		}
+
+		if len(LocationParameters(action.Parameters, "header")) != 1 {
+			t.Errorf("TestValidateLocationParameters Expected 1 header parameter: %s", action.Parameters)
+		}
+	}
+}
+
+func TestLocationParameterFlags(t *testing.T) {
+	group := []string{
+		" * @ref /User/Get",
+		" * @uri GET /api/user/{userId}",
+		" * @path {Integer} userId",
+		" * @query {String} fields",
+		" * @query:optional {Integer} pageSize",
+		" * @header:required {String} X-Auth-Token",
+	}
+
+	action, err := GenerateAction(NewLines(group), map[string][]Line{})
+
+	if err != nil {
+		t.Errorf("TestLocationParameterFlags Unexpected error: %s", err)
+		return
+	}
+
+	expected := map[string]string{
+		"userId":       "required",
+		"fields":       "",
+		"pageSize":     "optional",
+		"X-Auth-Token": "required",
+	}
+
+	for _, parameter := range action.Parameters {
+		if flag, ok := expected[parameter.Name]; !ok || parameter.Flag != flag {
+			t.Errorf("TestLocationParameterFlags Flag Mismatch: %s\nExpected: %s\n  Actual: %s", parameter.Name, flag, parameter.Flag)
+		}
+	}
+
+	if len(action.Parameters) != len(expected) {
+		t.Errorf("TestLocationParameterFlags Expected %d parameters: %v", len(expected), action.Parameters)
+	}
+}
+
+func TestGenerateResponses(t *testing.T) {
+	group := []string{
+		" * @ref /User/Create",
+		" * @uri /api/user",
+		" * @return {Boolean} ok",
+		" * @failure {String} error",
+		" * @status 201 The user was created.",
+		" * @success:201 {Object} user",
+		" * @success:201 {Integer} user.id",
+		" * @failure:409 {Integer} existing",
+	}
+
+	action, err := GenerateAction(NewLines(group), map[string][]Line{})
+
+	if err != nil {
+		t.Errorf("TestGenerateResponses Unexpected error: %s", err)
+		return
+	}
+
+	names := func(keyValues []KeyValue) []string {
+		result := make([]string, 0)
+
+		for _, keyValue := range keyValues {
+			result = append(result, keyValue.Name)
+		}
+
+		return result
+	}
+
+	if !reflect.DeepEqual(names(action.Returns), []string{"error", "ok"}) {
+		t.Errorf("TestGenerateResponses Returns Mismatch: %s", names(action.Returns))
+	}
+
+	if !reflect.DeepEqual(ResponseStatuses(action), []string{"201", "409"}) {
+		t.Errorf("TestGenerateResponses Statuses Mismatch: %s", ResponseStatuses(action))
+		return
+	}
+
+	created := action.Responses["201"]
+
+	if created.Description != "The user was created." ||
+		!reflect.DeepEqual(names(created.Returns), []string{"ok", "user"}) ||
+		len(created.Returns[1].Children) != 1 {
+		t.Errorf("TestGenerateResponses 201 Mismatch: %+v", created)
+	}
+
+	if !reflect.DeepEqual(names(action.Responses["409"].Returns), []string{"error", "existing", "ok"}) {
+		t.Errorf("TestGenerateResponses 409 Mismatch: %+v", action.Responses["409"])
+	}
+}
+
+func TestSourceTracking(t *testing.T) {
+	source := `/**
+ * ---ATOZAPI---
+ * @name Lookup
+ * @ref /MyApp/User/Lookup
+ * @parameter {Integer} id
+ * @return {String} name
+ * ---ATOZEND---
+ */
+`
+
+	groups, err := ParseGroups(bufio.NewReader(bytes.NewBufferString(source)), "src/user.js")
+
+	if err != nil {
+		t.Errorf("TestSourceTracking Unexpected error: %s", err)
+		return
+	}
+
+	actionGroups, err := GetActionGroups(groups)
+
+	if err != nil {
+		t.Errorf("TestSourceTracking Unexpected error: %s", err)
+		return
+	}
+
+	action, err := GenerateAction(actionGroups["/MyApp/User/Lookup"], map[string][]Line{})
+
+	if err != nil {
+		t.Errorf("TestSourceTracking Unexpected error: %s", err)
+		return
+	}
+
+	if !reflect.DeepEqual(action.Source, &Source{"src/user.js", 4}) {
+		t.Errorf("TestSourceTracking Action Mismatch: %v", action.Source)
+	}
+
+	if !reflect.DeepEqual(action.Parameters[0].Source, &Source{"src/user.js", 5}) {
+		t.Errorf("TestSourceTracking Parameter Mismatch: %v", action.Parameters[0].Source)
+	}
+
+	if !reflect.DeepEqual(action.Returns[0].Source, &Source{"src/user.js", 6}) {
+		t.Errorf("TestSourceTracking Return Mismatch: %v", action.Returns[0].Source)
+	}
+
+	invalid := []Line{
+		Line{" * @ref /MyApp/User/Lookup", Source{"src/user.js", 4}},
+		Line{" * @parameter {Unknown} id", Source{"src/user.js", 5}},
+	}
+
+	_, err = GenerateAction(invalid, map[string][]Line{})
+
+	if err == nil || !strings.HasPrefix(err.Error(), "src/user.js:5:4: ") {
+		t.Errorf("TestSourceTracking Error Mismatch: %v", err)
+	}
+
+	_, err = ParseGroups(bufio.NewReader(bytes.NewBufferString("\n * ---ATOZOBJ---\n * @ref /User\n")), "src/user.js")
+
+	if err == nil || !strings.HasPrefix(err.Error(), "src/user.js:2:4: ") {
+		t.Errorf("TestSourceTracking Unclosed Error Mismatch: %v", err)
+	}
+}
+
+func TestResolveRefs(t *testing.T) {
+	newApiSpec := func() ApiSpec {
+		return ApiSpec{
+			[]Action{
+				Action{
+					Ref: "/MyApp/User/Lookup",
+					Parameters: []KeyValue{
+						KeyValue{Name: "user", Type: "#/Application/User#"},
+					},
+					Returns: []KeyValue{
+						KeyValue{Name: "result", Type: "object", Children: []KeyValue{
+							KeyValue{Name: "manager", Type: "#/Application/Manager#", Source: &Source{"src/user.js", 9}},
+						}},
+					},
+				},
+			},
+			[]Object{
+				Object{Ref: "/Application/User", Properties: []KeyValue{
+					KeyValue{Name: "parent", Type: "#/Application/User#"},
+				}},
+			},
+		}
+	}
+
+	apiSpec := newApiSpec()
+
+	err := ResolveRefs(&apiSpec, false)
+
+	diagnostics, ok := err.(Diagnostics)
+
+	if !ok || len(diagnostics) != 1 || diagnostics[0].Severity != SeverityError ||
+		diagnostics[0].Line != 9 || diagnostics[0].Code != CodeUnknownRef {
+		t.Errorf("TestResolveRefs Strict Mismatch: %v", err)
+	}
+
+	if apiSpec.Actions[0].Parameters[0].ResolvedRef != "/Application/User" ||
+		apiSpec.Objects[0].Properties[0].ResolvedRef != "/Application/User" ||
+		apiSpec.Actions[0].Returns[0].Children[0].ResolvedRef != "" {
+		t.Errorf("TestResolveRefs ResolvedRef Mismatch: %v", apiSpec)
+	}
+
+	apiSpec = newApiSpec()
+
+	err = ResolveRefs(&apiSpec, true)
+
+	if diagnostics, ok := err.(Diagnostics); !ok || diagnostics.HasErrors() {
+		t.Errorf("TestResolveRefs Lax should only warn: %v", err)
+	}
+}
+
+func TestGenerateActionDeprecated(t *testing.T) {
+	group := []string{
+		" * @ref /MyApp/User/Find",
+		" * @deprecated Use /MyApp/User/Lookup instead.",
+	}
+
+	action, err := GenerateAction(NewLines(group), map[string][]Line{})
+
+	if err != nil {
+		t.Errorf("TestGenerateActionDeprecated Unexpected error: %s", err)
+		return
+	}
+
+	if !action.Deprecated || !reflect.DeepEqual(action.Notes, []string{"Use /MyApp/User/Lookup instead."}) {
+		t.Errorf("TestGenerateActionDeprecated Mismatch: %t %s", action.Deprecated, action.Notes)
+	}
+
+	object, err := GenerateObject(NewLines([]string{" * @ref /Application/User", " * @deprecated"}), map[string][]Line{})
+
+	if err != nil || !object.Deprecated || len(object.Notes) != 0 {
+		t.Errorf("TestGenerateActionDeprecated Object Mismatch: %t %s %v", object.Deprecated, object.Notes, err)
+	}
+}
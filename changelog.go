@@ -0,0 +1,195 @@
+package atoz
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// The changes made in a single version.
+type Release struct {
+	Version string
+	Changes []Change
+}
+
+var changelogCategories = []string{"Added", "Changed", "Deprecated", "Removed"}
+
+// Receive a change
+// Return the changelog section it's listed under.
+func ChangeCategory(change Change) string {
+	switch change.Kind {
+	case ChangeActionAdded, ChangeObjectAdded, ChangeResponseAdded, ChangeValueAdded:
+		return "Added"
+	case ChangeActionDeprecated, ChangeObjectDeprecated:
+		return "Deprecated"
+	case ChangeActionRemoved, ChangeObjectRemoved, ChangeResponseRemoved, ChangeValueRemoved:
+		return "Removed"
+	}
+
+	return "Changed"
+}
+
+// Render the releases, newest first, as a Markdown changelog.  Within each
+// section whole actions and objects are listed before their fields.
+func GenerateChangelog(releases []Release, title string) []byte {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("# " + title + " Changelog\n")
+
+	for _, release := range releases {
+		buffer.WriteString("\n## " + release.Version + "\n")
+
+		if len(release.Changes) == 0 {
+			buffer.WriteString("\nNo changes to the API.\n")
+			continue
+		}
+
+		for _, category := range changelogCategories {
+			entries := make([]string, 0)
+			fieldEntries := make([]string, 0)
+
+			for _, change := range release.Changes {
+				if ChangeCategory(change) != category {
+					continue
+				}
+
+				entry := "- "
+
+				if change.Breaking {
+					entry += "**Breaking:** "
+				}
+
+				entry += "`" + change.Ref + "` - " + change.Message + "\n"
+
+				if len(change.Path) > 0 {
+					fieldEntries = append(fieldEntries, entry)
+				} else {
+					entries = append(entries, entry)
+				}
+			}
+
+			if len(entries)+len(fieldEntries) == 0 {
+				continue
+			}
+
+			buffer.WriteString("\n### " + category + "\n\n")
+			buffer.WriteString(strings.Join(append(entries, fieldEntries...), ""))
+		}
+	}
+
+	return buffer.Bytes()
+}
+
+// Receive a list of specs, oldest first, and the version of each
+// Return the changes of every version after the first, newest first.
+func GenerateReleases(versions []string, apiSpecs []ApiSpec) []Release {
+	releases := make([]Release, 0)
+
+	for i := len(apiSpecs) - 1; i > 0; i-- {
+		releases = append(releases, Release{versions[i], DiffApiSpecs(apiSpecs[i-1], apiSpecs[i])})
+	}
+
+	return releases
+}
+
+// Return the tags of the git repository that dir is in, oldest version first.
+func GitTags(dir string) ([]string, error) {
+	command := exec.Command("git", "tag", "--list", "--sort=v:refname")
+	command.Dir = dir
+
+	output, err := command.Output()
+
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]string, 0)
+
+	for _, tag := range strings.Split(string(output), "\n") {
+		if tag = strings.TrimSpace(tag); len(tag) > 0 {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags, nil
+}
+
+// Build the spec of the source tree in dir as it was at a git tag.  The files
+// are checked out to a temporary directory, so the sources in the spec point
+// there.  Trees from old tags may not pass today's checks, so unknown refs are
+// only warnings, and the error is the Diagnostics of the tree.
+func GitApiSpec(dir string, tag string) (ApiSpec, error) {
+	tempDir, err := ioutil.TempDir("", "atoz")
+
+	if err != nil {
+		return ApiSpec{}, err
+	}
+
+	defer os.RemoveAll(tempDir)
+
+	command := exec.Command("git", "archive", "--format=tar", tag, ".")
+	command.Dir = dir
+
+	output, err := command.Output()
+
+	if err != nil {
+		return ApiSpec{}, err
+	}
+
+	err = extractTar(bytes.NewReader(output), tempDir)
+
+	if err != nil {
+		return ApiSpec{}, err
+	}
+
+	return Parser{Lax: true}.ParseDir(tempDir)
+}
+
+func extractTar(r io.Reader, dir string) error {
+	reader := tar.NewReader(r)
+
+	for {
+		header, err := reader.Next()
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(header.Name))
+
+		if !strings.HasPrefix(path, filepath.Clean(dir)+string(os.PathSeparator)) {
+			continue
+		}
+
+		err = os.MkdirAll(filepath.Dir(path), 0755)
+
+		if err != nil {
+			return err
+		}
+
+		contents, err := ioutil.ReadAll(reader)
+
+		if err != nil {
+			return err
+		}
+
+		err = ioutil.WriteFile(path, contents, 0644)
+
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package atoz
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGenerateChangelog(t *testing.T) {
+	releases := []Release{
+		Release{Version: "2.0.0", Changes: []Change{
+			Change{Kind: ChangeValueAdded, Ref: "/MyApp/User/Lookup", Path: "parameters.page", Message: "Parameter page added"},
+			Change{Kind: ChangeActionAdded, Ref: "/MyApp/User/Create", Message: "Action added"},
+			Change{Kind: ChangeMethodChanged, Ref: "/MyApp/User/Lookup", Breaking: true, Message: "Method changed from POST to GET"},
+			Change{Kind: ChangeActionDeprecated, Ref: "/MyApp/User/Find", Message: "Action deprecated"},
+			Change{Kind: ChangeValueRemoved, Ref: "/MyApp/User/Lookup", Path: "returns.email", Breaking: true, Message: "Return email removed"},
+		}},
+		Release{Version: "1.1.0", Changes: []Change{}},
+	}
+
+	expected := "# My API Changelog\n" +
+		"\n## 2.0.0\n" +
+		"\n### Added\n\n" +
+		"- `/MyApp/User/Create` - Action added\n" +
+		"- `/MyApp/User/Lookup` - Parameter page added\n" +
+		"\n### Changed\n\n" +
+		"- **Breaking:** `/MyApp/User/Lookup` - Method changed from POST to GET\n" +
+		"\n### Deprecated\n\n" +
+		"- `/MyApp/User/Find` - Action deprecated\n" +
+		"\n### Removed\n\n" +
+		"- **Breaking:** `/MyApp/User/Lookup` - Return email removed\n" +
+		"\n## 1.1.0\n" +
+		"\nNo changes to the API.\n"
+
+	if result := string(GenerateChangelog(releases, "My API")); result != expected {
+		t.Errorf("TestGenerateChangelog Mismatch:\nExpected:\n%s\n  Actual:\n%s", expected, result)
+	}
+}
+
+func TestGenerateReleases(t *testing.T) {
+	first := ApiSpec{Actions: []Action{Action{Ref: "/A"}}, Objects: []Object{}}
+	second := ApiSpec{Actions: []Action{Action{Ref: "/A"}, Action{Ref: "/B"}}, Objects: []Object{}}
+
+	releases := GenerateReleases([]string{"", "v1", "v2"}, []ApiSpec{ApiSpec{}, first, second})
+
+	expected := []Release{
+		Release{Version: "v2", Changes: []Change{Change{Kind: ChangeActionAdded, Ref: "/B", Message: "Action added"}}},
+		Release{Version: "v1", Changes: []Change{Change{Kind: ChangeActionAdded, Ref: "/A", Message: "Action added"}}},
+	}
+
+	if !reflect.DeepEqual(releases, expected) {
+		t.Errorf("TestGenerateReleases Mismatch:\nExpected: %v\n  Actual: %v", expected, releases)
+	}
+}
+
+func TestGitApiSpec(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+
+	contents := func(ref string) string {
+		return "/**\n * ---ATOZAPI---\n * @name Action\n * @ref " + ref + "\n * ---ATOZEND---\n */\n"
+	}
+
+	dir := writeSpecFiles(t, map[string]string{filepath.Join("src", "user.js"): contents("/A")})
+	defer os.RemoveAll(dir)
+
+	source := filepath.Join(dir, "src", "user.js")
+
+	git := func(args ...string) {
+		command := exec.Command("git", append([]string{"-c", "user.name=atoz", "-c", "user.email=atoz@example.com"}, args...)...)
+		command.Dir = dir
+
+		if output, err := command.CombinedOutput(); err != nil {
+			t.Fatalf("TestGitApiSpec git %s: %s %s", args, err, output)
+		}
+	}
+
+	write := func(ref string) {
+		if err := ioutil.WriteFile(source, []byte(contents(ref)), 0644); err != nil {
+			t.Fatalf("TestGitApiSpec Unexpected error: %s", err)
+		}
+	}
+
+	git("init", "-q")
+	git("add", "-A")
+	git("commit", "-q", "-m", "First")
+	git("tag", "v1.0.0")
+	write("/B")
+	git("commit", "-q", "-a", "-m", "Second")
+	git("tag", "v1.10.0")
+	git("tag", "v1.2.0", "HEAD~1")
+
+	tags, err := GitTags(filepath.Dir(source))
+
+	if err != nil || !reflect.DeepEqual(tags, []string{"v1.0.0", "v1.2.0", "v1.10.0"}) {
+		t.Errorf("TestGitApiSpec Tags Mismatch: %v %v", tags, err)
+	}
+
+	apiSpec, err := GitApiSpec(filepath.Dir(source), "v1.0.0")
+
+	if err != nil || len(apiSpec.Actions) != 1 || apiSpec.Actions[0].Ref != "/A" {
+		t.Errorf("TestGitApiSpec Spec Mismatch: %v %v", apiSpec, err)
+	}
+}
@@ -0,0 +1,592 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/funnylookinhat/atoz"
+)
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "lint" {
+		os.Exit(lintMain(os.Args[2:]))
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		os.Exit(diffMain(os.Args[2:]))
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "changelog" {
+		os.Exit(changelogMain(os.Args[2:]))
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "mock" {
+		os.Exit(mockMain(os.Args[2:]))
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "verify" {
+		os.Exit(verifyMain(os.Args[2:]))
+	}
+
+	var dir string
+	var output string
+	var format string
+	var title string
+	var version string
+	var templates string
+	var packageName string
+	var split bool
+	var baseUrl string
+	var examples bool
+	var sources bool
+	var lax bool
+	var diagnosticsFormat string
+	var diagnosticsOutput string
+
+	flag.StringVar(&dir, "dir", "./", "Path to source tree.")
+	flag.StringVar(&output, "output", "", "File to write JSON to, or directory for multi-file formats.")
+	flag.StringVar(&format, "format", "json", "Output format: json, openapi, swagger, jsonschema, html, markdown, postman, insomnia, typescript, go or python.")
+	flag.StringVar(&title, "title", "API", "API title for generated documents and collections.")
+	flag.StringVar(&version, "version", "1.0.0", "API version for openapi and swagger output.")
+	flag.StringVar(&templates, "templates", "", "Directory of template overrides for html output.")
+	flag.StringVar(&packageName, "package", "api", "Package name for go output.")
+	flag.BoolVar(&split, "split", false, "Write markdown output as one file per ref.")
+	flag.BoolVar(&examples, "examples", false, "Include example requests and responses for each action in json output.")
+	flag.BoolVar(&sources, "sources", false, "Include the file and line of each action, object and key/value in json output.")
+	flag.StringVar(&baseUrl, "base-url", "http://localhost", "Default baseUrl for postman and insomnia output.")
+	flag.BoolVar(&lax, "lax", false, "Warn about {#/Ref#} types that match no object instead of failing.")
+	flag.StringVar(&diagnosticsFormat, "diagnostics-format", "text", "Format of reported errors and warnings: text, json or sarif.")
+	flag.StringVar(&diagnosticsOutput, "diagnostics-output", "", "File to write errors and warnings to instead of stderr.")
+
+	flag.Parse()
+
+	var apiSpec atoz.ApiSpec
+	var resultJson []byte
+	var resultFiles map[string][]byte
+	var err error
+
+	parser := atoz.Parser{Lax: lax, Examples: examples, Sources: sources}
+
+	apiSpec, err = parser.ParseDir(dir)
+
+	// Report every problem found, and only stop if one of them is an error.
+	diagnostics, ok := err.(atoz.Diagnostics)
+
+	if err != nil && !ok {
+		log.Fatal(err)
+	}
+
+	err = writeDiagnostics(diagnostics, diagnosticsFormat, diagnosticsOutput)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if diagnostics.HasErrors() {
+		os.Exit(1)
+	}
+
+	info := atoz.ApiInfo{Title: title, Version: version}
+
+	switch format {
+	case "json":
+		resultJson, err = json.Marshal(apiSpec)
+	case "openapi":
+		resultJson, err = json.Marshal(atoz.GenerateOpenApi(apiSpec, info))
+	case "swagger":
+		resultJson, err = json.Marshal(atoz.GenerateSwagger(apiSpec, info))
+	case "jsonschema":
+		resultFiles, err = atoz.GenerateJsonSchemaFiles(apiSpec)
+	case "html":
+		var htmlTemplates map[string]string
+
+		htmlTemplates, err = atoz.LoadHtmlTemplates(templates)
+
+		if err == nil {
+			resultFiles, err = atoz.GenerateHtml(apiSpec, title, htmlTemplates)
+		}
+	case "markdown":
+		if split {
+			resultFiles = atoz.GenerateMarkdownFiles(apiSpec, title)
+		} else {
+			resultJson = atoz.GenerateMarkdown(apiSpec, title)
+		}
+	case "postman":
+		var collection atoz.PostmanCollection
+
+		collection, err = atoz.GeneratePostman(apiSpec, title, baseUrl)
+
+		if err == nil {
+			resultJson, err = json.Marshal(collection)
+		}
+	case "insomnia":
+		var export atoz.InsomniaExport
+
+		export, err = atoz.GenerateInsomnia(apiSpec, title, baseUrl)
+
+		if err == nil {
+			resultJson, err = json.Marshal(export)
+		}
+	case "typescript":
+		resultJson = atoz.GenerateTypeScript(apiSpec, title)
+	case "go":
+		resultJson, err = atoz.GenerateGo(apiSpec, title, packageName)
+	case "python":
+		resultJson = atoz.GeneratePython(apiSpec, title)
+	default:
+		err = fmt.Errorf("Unknown format: %s", format)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	if resultFiles != nil {
+		if len(output) == 0 {
+			log.Fatal(fmt.Errorf("The %s format requires an -output directory.", format))
+			return
+		}
+
+		err = writeFiles(output, resultFiles)
+
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
+	// If no output file specified, throw to stdout
+	if len(output) == 0 {
+		fmt.Printf("%s", resultJson)
+		return
+	}
+
+	err = ioutil.WriteFile(output, resultJson, 0644)
+
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+}
+
+// atoz lint -dir some/path -disable unused-definition,missing-uri
+// Exits with 1 if anything is reported.
+func lintMain(args []string) int {
+	var dir string
+	var disable string
+	var rules bool
+	var diagnosticsFormat string
+	var diagnosticsOutput string
+
+	flags := flag.NewFlagSet("lint", flag.ExitOnError)
+	flags.StringVar(&dir, "dir", "./", "Path to source tree.")
+	flags.StringVar(&disable, "disable", "", "Comma separated IDs of lint rules to skip.")
+	flags.BoolVar(&rules, "rules", false, "List the lint rules and exit.")
+	flags.StringVar(&diagnosticsFormat, "diagnostics-format", "text", "Format of reported errors and warnings: text, json or sarif.")
+	flags.StringVar(&diagnosticsOutput, "diagnostics-output", "", "File to write errors and warnings to instead of stderr.")
+	flags.Parse(args)
+
+	if rules {
+		for _, rule := range atoz.LintRules {
+			fmt.Printf("%-20s %s\n", rule.Id, rule.Description)
+		}
+
+		return 0
+	}
+
+	disabled := make(map[string]bool)
+
+	for _, id := range strings.Split(disable, ",") {
+		if id = strings.TrimSpace(id); len(id) > 0 {
+			disabled[id] = true
+		}
+	}
+
+	files, err := atoz.FindFiles(dir)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	diagnostics, err := atoz.Lint(files, disabled)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = writeDiagnostics(diagnostics, diagnosticsFormat, diagnosticsOutput)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(diagnostics) == 0 {
+		return 0
+	}
+
+	return 1
+}
+
+// atoz diff old.json new.json
+// Either side can also be a source tree.  Exits with 1 if anything breaks
+// clients.
+func diffMain(args []string) int {
+	var format string
+
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	flags.StringVar(&format, "format", "text", "Output format: text or json.")
+	flags.Parse(args)
+
+	if flags.NArg() != 2 {
+		log.Fatal(fmt.Errorf("Usage: atoz diff [-format json] old new"))
+	}
+
+	oldSpec, err := loadApiSpec(flags.Arg(0))
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	newSpec, err := loadApiSpec(flags.Arg(1))
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	changes := atoz.DiffApiSpecs(oldSpec, newSpec)
+
+	switch format {
+	case "text":
+		for _, change := range changes {
+			fmt.Println(change)
+		}
+	case "json":
+		result, err := json.MarshalIndent(changes, "", "  ")
+
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("%s\n", result)
+	default:
+		log.Fatal(fmt.Errorf("Unknown format: %s", format))
+	}
+
+	if atoz.HasBreakingChanges(changes) {
+		return 1
+	}
+
+	return 0
+}
+
+// atoz changelog old.json new.json
+// atoz changelog -dir some/path -tags
+// With -tags, every git tag of the tree becomes a release, and the tree as it
+// is now is added under -version if anything changed since the last tag.
+func changelogMain(args []string) int {
+	var title string
+	var version string
+	var dir string
+	var tags bool
+	var output string
+
+	flags := flag.NewFlagSet("changelog", flag.ExitOnError)
+	flags.StringVar(&title, "title", "API", "API title for the changelog heading.")
+	flags.StringVar(&version, "version", "Unreleased", "Heading for the newest changes.")
+	flags.StringVar(&dir, "dir", "./", "Path to source tree, for -tags.")
+	flags.BoolVar(&tags, "tags", false, "Build a release for every git tag of the source tree.")
+	flags.StringVar(&output, "output", "", "File to write the changelog to.")
+	flags.Parse(args)
+
+	var releases []atoz.Release
+
+	if tags {
+		tagNames, err := atoz.GitTags(dir)
+
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		// The first tag is compared to nothing, so it lists everything.
+		versions := []string{""}
+		apiSpecs := []atoz.ApiSpec{atoz.ApiSpec{}}
+
+		for _, tag := range tagNames {
+			apiSpec, err := atoz.GitApiSpec(dir, tag)
+
+			if diagnostics, ok := err.(atoz.Diagnostics); ok {
+				fmt.Fprintf(os.Stderr, "%s: %d problems found, some changes may be missing\n", tag, len(diagnostics))
+			} else if err != nil {
+				log.Fatal(err)
+			}
+
+			versions = append(versions, tag)
+			apiSpecs = append(apiSpecs, apiSpec)
+		}
+
+		apiSpec, err := loadApiSpec(dir)
+
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		versions = append(versions, version)
+		apiSpecs = append(apiSpecs, apiSpec)
+
+		releases = atoz.GenerateReleases(versions, apiSpecs)
+
+		if len(releases) > 0 && len(releases[0].Changes) == 0 {
+			releases = releases[1:]
+		}
+	} else {
+		if flags.NArg() != 2 {
+			log.Fatal(fmt.Errorf("Usage: atoz changelog [-version 2.0.0] old new, or atoz changelog -dir some/path -tags"))
+		}
+
+		oldSpec, err := loadApiSpec(flags.Arg(0))
+
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		newSpec, err := loadApiSpec(flags.Arg(1))
+
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		releases = []atoz.Release{atoz.Release{Version: version, Changes: atoz.DiffApiSpecs(oldSpec, newSpec)}}
+	}
+
+	changelog := atoz.GenerateChangelog(releases, title)
+
+	if len(output) == 0 {
+		fmt.Printf("%s", changelog)
+		return 0
+	}
+
+	err := ioutil.WriteFile(output, changelog, 0644)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return 0
+}
+
+// atoz mock -dir some/path -listen :8080
+// Serves until killed, reloading whenever the source tree changes.
+func mockMain(args []string) int {
+	var dir string
+	var listen string
+	var lax bool
+	var interval time.Duration
+
+	flags := flag.NewFlagSet("mock", flag.ExitOnError)
+	flags.StringVar(&dir, "dir", "./", "Path to source tree.")
+	flags.StringVar(&listen, "listen", ":8080", "Address to serve on.")
+	flags.BoolVar(&lax, "lax", false, "Warn about {#/Ref#} types that match no object instead of failing.")
+	flags.DurationVar(&interval, "interval", time.Second, "How often to check the source tree for changes.")
+	flags.Parse(args)
+
+	mockServer := atoz.NewMockServer(dir, atoz.Parser{Lax: lax})
+
+	_, err := mockServer.Reload()
+
+	if diagnostics, ok := err.(atoz.Diagnostics); ok && !diagnostics.HasErrors() {
+		log.Printf("%s", err)
+	} else if err != nil {
+		log.Fatal(err)
+	}
+
+	go mockServer.Watch(interval)
+
+	log.Printf("Serving mock responses for %s on %s", dir, listen)
+
+	err = http.ListenAndServe(listen, mockServer)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return 0
+}
+
+// atoz verify -dir some/path -base-url http://localhost:8080
+// Exits with 1 if any action fails.
+func verifyMain(args []string) int {
+	var dir string
+	var baseUrl string
+	var format string
+	var timeout time.Duration
+	var headers headerFlags
+
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	flags.StringVar(&dir, "dir", "./", "Path to source tree, or a JSON spec written by atoz.")
+	flags.StringVar(&baseUrl, "base-url", "", "Where the service to verify is running.")
+	flags.StringVar(&format, "format", "text", "Output format: text or json.")
+	flags.DurationVar(&timeout, "timeout", 10*time.Second, "How long to wait for each response.")
+	flags.Var(&headers, "header", "A header to send with every request, as \"Name: Value\".  Can be repeated.")
+	flags.Parse(args)
+
+	if len(baseUrl) == 0 {
+		log.Fatal(fmt.Errorf("Usage: atoz verify -base-url http://localhost:8080 [-dir some/path]"))
+	}
+
+	apiSpec, err := loadApiSpec(dir)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	verifier := atoz.Verifier{
+		BaseUrl: baseUrl,
+		Header:  http.Header(headers),
+		Client:  &http.Client{Timeout: timeout},
+	}
+
+	results := verifier.Verify(apiSpec)
+
+	failed := 0
+
+	for _, result := range results {
+		if !result.Passed {
+			failed++
+		}
+	}
+
+	switch format {
+	case "text":
+		for _, result := range results {
+			fmt.Print(result)
+		}
+
+		fmt.Printf("\n%d passed, %d failed\n", len(results)-failed, failed)
+	case "json":
+		result, err := json.MarshalIndent(results, "", "  ")
+
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("%s\n", result)
+	default:
+		log.Fatal(fmt.Errorf("Unknown format: %s", format))
+	}
+
+	if failed > 0 {
+		return 1
+	}
+
+	return 0
+}
+
+// Collects repeated -header "Name: Value" flags.
+type headerFlags http.Header
+
+func (h *headerFlags) String() string {
+	return fmt.Sprint(*h)
+}
+
+func (h *headerFlags) Set(value string) error {
+	parts := strings.SplitN(value, ":", 2)
+
+	if len(parts) != 2 || len(strings.TrimSpace(parts[0])) == 0 {
+		return fmt.Errorf("Expected a header as \"Name: Value\", got %s", value)
+	}
+
+	if *h == nil {
+		*h = make(headerFlags)
+	}
+
+	http.Header(*h).Add(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+
+	return nil
+}
+
+// Receive the path of either a JSON spec written by atoz or a source tree
+// Return the spec it describes.
+func loadApiSpec(path string) (atoz.ApiSpec, error) {
+	var apiSpec atoz.ApiSpec
+
+	info, err := os.Stat(path)
+
+	if err != nil {
+		return apiSpec, err
+	}
+
+	if !info.IsDir() {
+		contents, err := ioutil.ReadFile(path)
+
+		if err != nil {
+			return apiSpec, err
+		}
+
+		err = json.Unmarshal(contents, &apiSpec)
+
+		return apiSpec, err
+	}
+
+	apiSpec, err = atoz.Parser{}.ParseDir(path)
+
+	if diagnostics, ok := err.(atoz.Diagnostics); ok && !diagnostics.HasErrors() {
+		return apiSpec, nil
+	}
+
+	return apiSpec, err
+}
+
+// Write the report to the output file, or stderr when there isn't one.
+func writeDiagnostics(diagnostics atoz.Diagnostics, format string, output string) error {
+	report, err := atoz.FormatDiagnostics(diagnostics, format)
+
+	if err != nil {
+		return err
+	}
+
+	// Nothing is printed to stderr on a clean run, while a report file is
+	// always written.
+	if len(output) == 0 {
+		if len(diagnostics) == 0 {
+			return nil
+		}
+
+		_, err = os.Stderr.Write(report)
+
+		return err
+	}
+
+	return ioutil.WriteFile(output, report, 0644)
+}
+
+func writeFiles(dir string, files map[string][]byte) error {
+	var err error
+
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+
+		err = os.MkdirAll(filepath.Dir(path), 0755)
+
+		if err != nil {
+			return err
+		}
+
+		err = ioutil.WriteFile(path, contents, 0644)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
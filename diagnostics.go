@@ -0,0 +1,206 @@
+package atoz
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Codes identify the kind of problem so tooling can group or filter them.
+const (
+	CodeUnreadableFile    = "unreadable-file"
+	CodeUnclosedGroup     = "unclosed-group"
+	CodeInvalidGroup      = "invalid-group"
+	CodeMissingRef        = "missing-ref"
+	CodeInvalidLine       = "invalid-line"
+	CodeMissingDefinition = "missing-definition"
+	CodeConflictingMethod = "conflicting-method"
+	CodeInvalidExample    = "invalid-example"
+	CodeInvalidLocation   = "invalid-location"
+	CodeDuplicateRoute    = "duplicate-route"
+	CodeUnknownRef        = "unknown-ref"
+)
+
+var codeDescriptions = map[string]string{
+	CodeUnreadableFile:    "A file couldn't be read.",
+	CodeUnclosedGroup:     "A group is missing its ---ATOZEND--- line.",
+	CodeInvalidGroup:      "A group doesn't start with a known ---ATOZ*--- line.",
+	CodeMissingRef:        "A group has no @ref.",
+	CodeInvalidLine:       "A line couldn't be parsed.",
+	CodeMissingDefinition: "An @include names a definition that doesn't exist.",
+	CodeConflictingMethod: "An action declares more than one method.",
+	CodeInvalidExample:    "An @example doesn't match any value, or doesn't fit its type.",
+	CodeInvalidLocation:   "A path, query or header parameter doesn't fit its uri or type.",
+	CodeDuplicateRoute:    "Two actions share a method and uri.",
+	CodeUnknownRef:        "A {#/Ref#} type doesn't match any object.",
+}
+
+// A single problem found while parsing, along with where it was found.  File
+// and Line are blank when the lines didn't come from a file, and Column is
+// blank when the problem isn't on a single line.
+type Diagnostic struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column,omitempty"`
+	Severity string `json:"severity"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+}
+
+// Receive
+// Diagnostic{"src/user.js", 12, 4, "error", "invalid-line", "Invalid type: Foo"}
+// Return src/user.js:12:4: error [invalid-line]: Invalid type: Foo
+func (d Diagnostic) Error() string {
+	location := ""
+
+	if len(d.File) > 0 {
+		location = d.File + ":" + fmt.Sprint(d.Line) + ": "
+	}
+
+	if len(d.File) > 0 && d.Column > 0 {
+		location = d.File + ":" + fmt.Sprint(d.Line) + ":" + fmt.Sprint(d.Column) + ": "
+	}
+
+	return location + d.Severity + " [" + d.Code + "]: " + d.Message
+}
+
+// Every problem found while parsing - returned as the error of GenerateApiSpec
+// so all of them can be reported at once.
+type Diagnostics []Diagnostic
+
+func (d Diagnostics) Error() string {
+	messages := make([]string, 0)
+
+	for _, diagnostic := range d {
+		messages = append(messages, diagnostic.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+func (d Diagnostics) HasErrors() bool {
+	for _, diagnostic := range d {
+		if diagnostic.Severity == SeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+type DiagnosticBySource Diagnostics
+
+func (a DiagnosticBySource) Len() int      { return len(a) }
+func (a DiagnosticBySource) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a DiagnosticBySource) Less(i, j int) bool {
+	if a[i].File != a[j].File {
+		return a[i].File < a[j].File
+	}
+
+	return a[i].Line < a[j].Line
+}
+
+// Add an error to the list.  Diagnostics are added as they are, anything else
+// becomes an error with the given code.  The same problem is often found more
+// than once - i.e. a bad line is read by both the parameters and the returns of
+// an action - so duplicates are dropped.
+func (d Diagnostics) Append(code string, err error) Diagnostics {
+	if err == nil {
+		return d
+	}
+
+	var diagnostics Diagnostics
+
+	switch err := err.(type) {
+	case Diagnostics:
+		diagnostics = err
+	case Diagnostic:
+		diagnostics = Diagnostics{err}
+	default:
+		diagnostics = Diagnostics{Diagnostic{"", 0, 0, SeverityError, code, err.Error()}}
+	}
+
+	for _, diagnostic := range diagnostics {
+		if !d.contains(diagnostic) {
+			d = append(d, diagnostic)
+		}
+	}
+
+	return d
+}
+
+func (d Diagnostics) contains(diagnostic Diagnostic) bool {
+	for _, existing := range d {
+		if existing == diagnostic {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Return the diagnostics as an error, or nil when there aren't any.
+func (d Diagnostics) Err() error {
+	if len(d) == 0 {
+		return nil
+	}
+
+	return d
+}
+
+// Receive an error found on a line and a code
+// Return a Diagnostic pointing at the line.
+func LineError(line Line, code string, err error) error {
+	err = SourceError(line.Source.Pointer(), code, err)
+
+	if diagnostic, ok := err.(Diagnostic); ok && diagnostic.Line > 0 && diagnostic.Column == 0 {
+		diagnostic.Column = LineColumn(line.Text)
+
+		return diagnostic
+	}
+
+	return err
+}
+
+// Receive
+//   - @parameter {Integer} id
+//
+// Return 4 - the column the declaration or group marker starts on, counting
+// from 1, or 0 if there's neither.
+func LineColumn(text string) int {
+	for _, start := range []string{"@", "---ATOZ"} {
+		if index := strings.Index(text, start); index >= 0 {
+			return utf8.RuneCountInString(text[:index]) + 1
+		}
+	}
+
+	return 0
+}
+
+func SourceError(source *Source, code string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := err.(Diagnostic); ok {
+		return err
+	}
+
+	if _, ok := err.(Diagnostics); ok {
+		return err
+	}
+
+	diagnostic := Diagnostic{"", 0, 0, SeverityError, code, err.Error()}
+
+	if source != nil {
+		diagnostic.File = source.File
+		diagnostic.Line = source.Line
+	}
+
+	return diagnostic
+}
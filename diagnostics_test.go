@@ -0,0 +1,124 @@
+package atoz
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+type testDiagnosticErrorCase struct {
+	diagnostic Diagnostic
+	result     string
+}
+
+var testDiagnosticErrorCases = []testDiagnosticErrorCase{
+	{
+		Diagnostic{File: "src/user.js", Line: 12, Column: 4, Severity: SeverityError, Code: CodeInvalidLine, Message: "Invalid type: Foo"},
+		"src/user.js:12:4: error [invalid-line]: Invalid type: Foo",
+	},
+	{
+		Diagnostic{Severity: SeverityWarning, Code: CodeDuplicateRoute, Message: "Duplicate route"},
+		"warning [duplicate-route]: Duplicate route",
+	},
+}
+
+func TestDiagnosticError(t *testing.T) {
+	for _, test := range testDiagnosticErrorCases {
+		if result := test.diagnostic.Error(); result != test.result {
+			t.Errorf("TestDiagnosticError Mismatch:\nExpected: %s\n  Actual: %s", test.result, result)
+		}
+	}
+}
+
+func TestDiagnosticsAppend(t *testing.T) {
+	var diagnostics Diagnostics
+
+	line := Line{Text: " * @parameter {Foo} id", Source: Source{File: "src/user.js", Line: 3}}
+
+	diagnostics = diagnostics.Append(CodeInvalidLine, nil)
+	diagnostics = diagnostics.Append(CodeInvalidLine, LineError(line, CodeInvalidLine, fmt.Errorf("Invalid type: Foo")))
+	diagnostics = diagnostics.Append(CodeInvalidLine, Diagnostics{
+		Diagnostic{File: "src/user.js", Line: 3, Column: 4, Severity: SeverityError, Code: CodeInvalidLine, Message: "Invalid type: Foo"},
+		Diagnostic{File: "src/user.js", Line: 4, Severity: SeverityWarning, Code: CodeDuplicateRoute, Message: "Duplicate route"},
+	})
+	diagnostics = diagnostics.Append(CodeInvalidGroup, fmt.Errorf("No source"))
+
+	expected := Diagnostics{
+		Diagnostic{File: "src/user.js", Line: 3, Column: 4, Severity: SeverityError, Code: CodeInvalidLine, Message: "Invalid type: Foo"},
+		Diagnostic{File: "src/user.js", Line: 4, Severity: SeverityWarning, Code: CodeDuplicateRoute, Message: "Duplicate route"},
+		Diagnostic{Severity: SeverityError, Code: CodeInvalidGroup, Message: "No source"},
+	}
+
+	if !reflect.DeepEqual(diagnostics, expected) {
+		t.Errorf("TestDiagnosticsAppend Mismatch:\nExpected: %v\n  Actual: %v", expected, diagnostics)
+	}
+
+	if !diagnostics.HasErrors() || (Diagnostics{expected[1]}).HasErrors() {
+		t.Errorf("TestDiagnosticsAppend HasErrors Mismatch")
+	}
+
+	if (Diagnostics{}).Err() != nil {
+		t.Errorf("TestDiagnosticsAppend Err should be nil without diagnostics")
+	}
+}
+
+func TestGenerateApiSpecDiagnostics(t *testing.T) {
+	sources := map[string]string{
+		"a.js": `/**
+ * ---ATOZAPI---
+ * @name Lookup
+ * @ref /MyApp/User/Lookup
+ * @parameter {Foo} id
+ * @parameter {Integer} auth.id
+ * @returns {String} name
+ * ---ATOZEND---
+ */
+`,
+		"b.js": `/**
+ * ---ATOZOBJ---
+ * @name User
+ * @ref /Application/User
+ * @include /Missing
+ * ---ATOZEND---
+ * ---ATOZOBJ---
+ * @name Unclosed
+`,
+	}
+
+	dir := writeSpecFiles(t, sources)
+	defer os.RemoveAll(dir)
+
+	files := make([]string, 0)
+
+	for name := range sources {
+		files = append(files, filepath.Join(dir, name))
+	}
+
+	_, err := GenerateApiSpec(files, false)
+
+	diagnostics, ok := err.(Diagnostics)
+
+	if !ok {
+		t.Errorf("TestGenerateApiSpecDiagnostics Expected Diagnostics: %v", err)
+		return
+	}
+
+	result := make([]string, 0)
+
+	for _, diagnostic := range diagnostics {
+		result = append(result, fmt.Sprintf("%s:%d %s", filepath.Base(diagnostic.File), diagnostic.Line, diagnostic.Code))
+	}
+
+	expected := []string{
+		"a.js:5 invalid-line",
+		"a.js:7 invalid-line",
+		"b.js:5 missing-definition",
+		"b.js:7 unclosed-group",
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("TestGenerateApiSpecDiagnostics Mismatch:\nExpected: %s\n  Actual: %s", expected, result)
+	}
+}
@@ -0,0 +1,321 @@
+package atoz
+
+import (
+	"fmt"
+	"sort"
+)
+
+// A single difference between two versions of a spec.  Ref is the action or
+// object that changed, and Path the object.space of the key/value within it -
+// i.e. parameters.auth.id - when the change is to a key/value.
+type Change struct {
+	Kind     string `json:"kind"`
+	Ref      string `json:"ref"`
+	Path     string `json:"path,omitempty"`
+	Breaking bool   `json:"breaking"`
+	Message  string `json:"message"`
+}
+
+func (c Change) String() string {
+	severity := "non-breaking"
+
+	if c.Breaking {
+		severity = "breaking"
+	}
+
+	return "[" + severity + "] " + c.Ref + ": " + c.Message
+}
+
+const (
+	ChangeActionAdded        = "action-added"
+	ChangeActionRemoved      = "action-removed"
+	ChangeActionDeprecated   = "action-deprecated"
+	ChangeUriChanged         = "uri-changed"
+	ChangeMethodChanged      = "method-changed"
+	ChangeObjectAdded        = "object-added"
+	ChangeObjectRemoved      = "object-removed"
+	ChangeObjectDeprecated   = "object-deprecated"
+	ChangeResponseAdded      = "response-added"
+	ChangeResponseRemoved    = "response-removed"
+	ChangeValueAdded         = "value-added"
+	ChangeValueRemoved       = "value-removed"
+	ChangeValueRequired      = "value-required"
+	ChangeValueOptional      = "value-optional"
+	ChangeLocationChanged    = "location-changed"
+	ChangeTypeChanged        = "type-changed"
+	ChangeLimitNarrowed      = "limit-narrowed"
+	ChangeLimitWidened       = "limit-widened"
+	ChangeDescriptionChanged = "description-changed"
+)
+
+// The direction values flow in decides whether a change breaks clients - a
+// client has to send what a parameter asks for, and has to handle whatever a
+// return sends back.  Properties can be used in either direction, so they are
+// held to both.
+const (
+	directionIn   = "parameter"
+	directionOut  = "return"
+	directionBoth = "property"
+)
+
+func HasBreakingChanges(changes []Change) bool {
+	for _, change := range changes {
+		if change.Breaking {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Receive the old and new versions of a spec
+// Return every change between them, ordered by ref.
+func DiffApiSpecs(oldSpec ApiSpec, newSpec ApiSpec) []Change {
+	changes := make([]Change, 0)
+
+	oldActions := actionsByRef(oldSpec.Actions)
+	newActions := actionsByRef(newSpec.Actions)
+
+	for _, ref := range unionRefs(oldActions, newActions) {
+		oldAction, inOld := oldActions[ref]
+		newAction, inNew := newActions[ref]
+
+		if !inNew {
+			changes = append(changes, Change{ChangeActionRemoved, ref, "", true, "Action removed"})
+		} else if !inOld {
+			changes = append(changes, Change{ChangeActionAdded, ref, "", false, "Action added"})
+		} else {
+			changes = append(changes, DiffActions(oldAction, newAction)...)
+		}
+	}
+
+	oldObjects := ObjectsByRef(oldSpec.Objects)
+	newObjects := ObjectsByRef(newSpec.Objects)
+
+	for _, ref := range unionObjectRefs(oldObjects, newObjects) {
+		oldObject, inOld := oldObjects[ref]
+		newObject, inNew := newObjects[ref]
+
+		if !inNew {
+			changes = append(changes, Change{ChangeObjectRemoved, ref, "", true, "Object removed"})
+		} else if !inOld {
+			changes = append(changes, Change{ChangeObjectAdded, ref, "", false, "Object added"})
+		} else {
+			if !oldObject.Deprecated && newObject.Deprecated {
+				changes = append(changes, Change{ChangeObjectDeprecated, ref, "", false, "Object deprecated"})
+			}
+
+			changes = append(changes, DiffKeyValues(ref, "properties", directionBoth, oldObject.Properties, newObject.Properties)...)
+		}
+	}
+
+	return changes
+}
+
+func DiffActions(oldAction Action, newAction Action) []Change {
+	changes := make([]Change, 0)
+
+	ref := newAction.Ref
+
+	if oldAction.Uri != newAction.Uri {
+		changes = append(changes, Change{ChangeUriChanged, ref, "", true, fmt.Sprintf("Uri changed from %s to %s", oldAction.Uri, newAction.Uri)})
+	}
+
+	if ActionMethod(oldAction) != ActionMethod(newAction) {
+		changes = append(changes, Change{ChangeMethodChanged, ref, "", true, fmt.Sprintf("Method changed from %s to %s", ActionMethod(oldAction), ActionMethod(newAction))})
+	}
+
+	if !oldAction.Deprecated && newAction.Deprecated {
+		changes = append(changes, Change{ChangeActionDeprecated, ref, "", false, "Action deprecated"})
+	}
+
+	if oldAction.Description != newAction.Description {
+		changes = append(changes, Change{ChangeDescriptionChanged, ref, "", false, "Description changed"})
+	}
+
+	changes = append(changes, DiffKeyValues(ref, "parameters", directionIn, oldAction.Parameters, newAction.Parameters)...)
+	changes = append(changes, DiffKeyValues(ref, "returns", directionOut, oldAction.Returns, newAction.Returns)...)
+
+	statuses := make(map[string]bool)
+
+	for status, _ := range oldAction.Responses {
+		statuses[status] = true
+	}
+
+	for status, _ := range newAction.Responses {
+		statuses[status] = true
+	}
+
+	for _, status := range sortedKeys(statuses) {
+		oldResponse, inOld := oldAction.Responses[status]
+		newResponse, inNew := newAction.Responses[status]
+		path := "responses." + status
+
+		if !inNew {
+			changes = append(changes, Change{ChangeResponseRemoved, ref, path, true, "Response " + status + " removed"})
+		} else if !inOld {
+			changes = append(changes, Change{ChangeResponseAdded, ref, path, false, "Response " + status + " added"})
+		} else {
+			changes = append(changes, DiffKeyValues(ref, path, directionOut, oldResponse.Returns, newResponse.Returns)...)
+		}
+	}
+
+	return changes
+}
+
+// Compares two lists of key/values by their full object.space.  prefix is
+// added to the path of each change - i.e. "parameters".
+func DiffKeyValues(ref string, prefix string, direction string, oldKeyValues []KeyValue, newKeyValues []KeyValue) []Change {
+	changes := make([]Change, 0)
+
+	oldValues := flattenKeyValues(oldKeyValues, "")
+	newValues := flattenKeyValues(newKeyValues, "")
+
+	paths := make(map[string]bool)
+
+	for path, _ := range oldValues {
+		paths[path] = true
+	}
+
+	for path, _ := range newValues {
+		paths[path] = true
+	}
+
+	noun := map[string]string{directionIn: "Parameter", directionOut: "Return", directionBoth: "Property"}[direction]
+
+	for _, objectspace := range sortedKeys(paths) {
+		oldValue, inOld := oldValues[objectspace]
+		newValue, inNew := newValues[objectspace]
+		path := prefix + "." + objectspace
+
+		if !inNew {
+			// Clients can't be relying on a parameter being accepted, but they
+			// can be relying on a value being returned.
+			changes = append(changes, Change{ChangeValueRemoved, ref, path, direction != directionIn, fmt.Sprintf("%s %s removed", noun, objectspace)})
+			continue
+		}
+
+		if !inOld {
+			breaking := direction != directionOut && newValue.Flag == "required"
+			changes = append(changes, Change{ChangeValueAdded, ref, path, breaking, fmt.Sprintf("%s %s added", noun, objectspace)})
+			continue
+		}
+
+		if oldValue.Type != newValue.Type {
+			changes = append(changes, Change{ChangeTypeChanged, ref, path, true, fmt.Sprintf("%s %s changed type from %s to %s", noun, objectspace, oldValue.Type, newValue.Type)})
+		} else if narrowed, widened := compareLimits(oldValue.Limit, newValue.Limit); narrowed {
+			changes = append(changes, Change{ChangeLimitNarrowed, ref, path, direction != directionOut, fmt.Sprintf("%s %s limit narrowed from %s to %s", noun, objectspace, limitString(oldValue.Limit), limitString(newValue.Limit))})
+		} else if widened {
+			changes = append(changes, Change{ChangeLimitWidened, ref, path, direction != directionIn, fmt.Sprintf("%s %s limit widened from %s to %s", noun, objectspace, limitString(oldValue.Limit), limitString(newValue.Limit))})
+		}
+
+		if oldValue.Location != newValue.Location {
+			changes = append(changes, Change{ChangeLocationChanged, ref, path, true, fmt.Sprintf("%s %s moved from %s to %s", noun, objectspace, locationString(oldValue.Location), locationString(newValue.Location))})
+		}
+
+		if direction == directionIn && oldValue.Flag != "required" && newValue.Flag == "required" {
+			changes = append(changes, Change{ChangeValueRequired, ref, path, true, fmt.Sprintf("%s %s is now required", noun, objectspace)})
+		} else if direction == directionIn && oldValue.Flag == "required" && newValue.Flag != "required" {
+			changes = append(changes, Change{ChangeValueOptional, ref, path, false, fmt.Sprintf("%s %s is no longer required", noun, objectspace)})
+		}
+	}
+
+	return changes
+}
+
+// A limit of 0 is unlimited, and -1 means the type has no limit.
+func compareLimits(oldLimit int64, newLimit int64) (bool, bool) {
+	if oldLimit < 0 || newLimit < 0 || oldLimit == newLimit {
+		return false, false
+	}
+
+	if oldLimit == 0 {
+		return true, false
+	}
+
+	if newLimit == 0 {
+		return false, true
+	}
+
+	return newLimit < oldLimit, newLimit > oldLimit
+}
+
+func limitString(limit int64) string {
+	if limit == 0 {
+		return "unlimited"
+	}
+
+	return fmt.Sprint(limit)
+}
+
+func locationString(location string) string {
+	if len(location) == 0 {
+		return "body"
+	}
+
+	return location
+}
+
+func flattenKeyValues(keyValues []KeyValue, objectspace string) map[string]KeyValue {
+	flattened := make(map[string]KeyValue)
+
+	for _, keyValue := range keyValues {
+		flattened[objectspace+keyValue.Name] = keyValue
+
+		for path, child := range flattenKeyValues(keyValue.Children, objectspace+keyValue.Name+".") {
+			flattened[path] = child
+		}
+	}
+
+	return flattened
+}
+
+func actionsByRef(actions []Action) map[string]Action {
+	actionsByRef := make(map[string]Action)
+
+	for _, action := range actions {
+		actionsByRef[action.Ref] = action
+	}
+
+	return actionsByRef
+}
+
+func unionRefs(oldActions map[string]Action, newActions map[string]Action) []string {
+	refs := make(map[string]bool)
+
+	for ref, _ := range oldActions {
+		refs[ref] = true
+	}
+
+	for ref, _ := range newActions {
+		refs[ref] = true
+	}
+
+	return sortedKeys(refs)
+}
+
+func unionObjectRefs(oldObjects map[string]Object, newObjects map[string]Object) []string {
+	refs := make(map[string]bool)
+
+	for ref, _ := range oldObjects {
+		refs[ref] = true
+	}
+
+	for ref, _ := range newObjects {
+		refs[ref] = true
+	}
+
+	return sortedKeys(refs)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0)
+
+	for key, _ := range set {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,122 @@
+package atoz
+
+import (
+	"reflect"
+	"testing"
+)
+
+var testDiffOldSpec = ApiSpec{
+	Actions: []Action{
+		Action{
+			Name:   "User Lookup",
+			Ref:    "/MyApp/User/Lookup",
+			Method: "POST",
+			Uri:    "/User/Lookup",
+			Parameters: []KeyValue{
+				KeyValue{Name: "id", Type: "integer", Limit: -1, Location: "path", Flag: "required"},
+				KeyValue{Name: "filter", Type: "string", Limit: 32},
+				KeyValue{Name: "legacy", Type: "boolean", Limit: -1},
+			},
+			Returns: []KeyValue{
+				KeyValue{Name: "user", Type: "object", Limit: -1, Children: []KeyValue{
+					KeyValue{Name: "name", Type: "string", Limit: 64},
+					KeyValue{Name: "email", Type: "string", Limit: 0},
+				}},
+			},
+		},
+		Action{Name: "User Delete", Ref: "/MyApp/User/Delete", Method: "POST", Uri: "/User/Delete"},
+	},
+	Objects: []Object{
+		Object{Name: "User", Ref: "/Application/User", Properties: []KeyValue{
+			KeyValue{Name: "id", Type: "integer", Limit: -1},
+		}},
+	},
+}
+
+var testDiffNewSpec = ApiSpec{
+	Actions: []Action{
+		Action{
+			Name:   "User Lookup",
+			Ref:    "/MyApp/User/Lookup",
+			Method: "GET",
+			Uri:    "/User/Lookup",
+			Parameters: []KeyValue{
+				KeyValue{Name: "id", Type: "integer", Limit: -1, Location: "path", Flag: "required"},
+				KeyValue{Name: "filter", Type: "string", Limit: 16, Flag: "required"},
+				KeyValue{Name: "page", Type: "integer", Limit: -1},
+			},
+			Returns: []KeyValue{
+				KeyValue{Name: "user", Type: "object", Limit: -1, Children: []KeyValue{
+					KeyValue{Name: "name", Type: "string", Limit: 128},
+					KeyValue{Name: "created", Type: "string", Limit: 0},
+				}},
+			},
+		},
+		Action{Name: "User Create", Ref: "/MyApp/User/Create", Method: "POST", Uri: "/User/Create"},
+	},
+	Objects: []Object{
+		Object{Name: "User", Ref: "/Application/User", Properties: []KeyValue{
+			KeyValue{Name: "id", Type: "string", Limit: 0},
+		}},
+	},
+}
+
+func TestDiffApiSpecs(t *testing.T) {
+	expected := []Change{
+		Change{Kind: ChangeActionAdded, Ref: "/MyApp/User/Create", Message: "Action added"},
+		Change{Kind: ChangeActionRemoved, Ref: "/MyApp/User/Delete", Breaking: true, Message: "Action removed"},
+		Change{Kind: ChangeMethodChanged, Ref: "/MyApp/User/Lookup", Breaking: true, Message: "Method changed from POST to GET"},
+		Change{Kind: ChangeLimitNarrowed, Ref: "/MyApp/User/Lookup", Path: "parameters.filter", Breaking: true, Message: "Parameter filter limit narrowed from 32 to 16"},
+		Change{Kind: ChangeValueRequired, Ref: "/MyApp/User/Lookup", Path: "parameters.filter", Breaking: true, Message: "Parameter filter is now required"},
+		Change{Kind: ChangeValueRemoved, Ref: "/MyApp/User/Lookup", Path: "parameters.legacy", Message: "Parameter legacy removed"},
+		Change{Kind: ChangeValueAdded, Ref: "/MyApp/User/Lookup", Path: "parameters.page", Message: "Parameter page added"},
+		Change{Kind: ChangeValueAdded, Ref: "/MyApp/User/Lookup", Path: "returns.user.created", Message: "Return user.created added"},
+		Change{Kind: ChangeValueRemoved, Ref: "/MyApp/User/Lookup", Path: "returns.user.email", Breaking: true, Message: "Return user.email removed"},
+		Change{Kind: ChangeLimitWidened, Ref: "/MyApp/User/Lookup", Path: "returns.user.name", Breaking: true, Message: "Return user.name limit widened from 64 to 128"},
+		Change{Kind: ChangeTypeChanged, Ref: "/Application/User", Path: "properties.id", Breaking: true, Message: "Property id changed type from integer to string"},
+	}
+
+	changes := DiffApiSpecs(testDiffOldSpec, testDiffNewSpec)
+
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("TestDiffApiSpecs Mismatch:")
+
+		for _, change := range changes {
+			t.Errorf("\t%s %s %s", change.Kind, change.Path, change)
+		}
+	}
+
+	if !HasBreakingChanges(changes) {
+		t.Errorf("TestDiffApiSpecs Expected breaking changes")
+	}
+
+	if changes := DiffApiSpecs(testDiffOldSpec, testDiffOldSpec); len(changes) != 0 {
+		t.Errorf("TestDiffApiSpecs Expected no changes: %v", changes)
+	}
+}
+
+type testCompareLimitsCase struct {
+	oldLimit int64
+	newLimit int64
+	narrowed bool
+	widened  bool
+}
+
+var testCompareLimitsCases = []testCompareLimitsCase{
+	{-1, -1, false, false},
+	{0, 10, true, false},
+	{10, 0, false, true},
+	{10, 5, true, false},
+	{5, 10, false, true},
+	{5, 5, false, false},
+}
+
+func TestCompareLimits(t *testing.T) {
+	for _, test := range testCompareLimitsCases {
+		narrowed, widened := compareLimits(test.oldLimit, test.newLimit)
+
+		if narrowed != test.narrowed || widened != test.widened {
+			t.Errorf("TestCompareLimits Mismatch: %d to %d\nExpected: %t %t\n  Actual: %t %t", test.oldLimit, test.newLimit, test.narrowed, test.widened, narrowed, widened)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package atoz
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+)
+
+// Index objects by their ref so #Ref# types can be resolved.
+func ObjectsByRef(objects []Object) map[string]Object {
+	objectsByRef := make(map[string]Object)
+
+	for _, object := range objects {
+		objectsByRef[object.Ref] = object
+	}
+
+	return objectsByRef
+}
+
+// Set the exampleRequest and exampleResponse of every action.
+func AddExamples(apiSpec *ApiSpec) {
+	objects := ObjectsByRef(apiSpec.Objects)
+
+	for i, action := range apiSpec.Actions {
+		apiSpec.Actions[i].ExampleRequest = ExampleKeyValues(LocationParameters(action.Parameters, ""), objects)
+		apiSpec.Actions[i].ExampleResponse = ExampleResponse(action, objects, "success")
+	}
+}
+
+// Receive an action and either "success" or "failure"
+// Return an example of the values returned in that case.
+func ExampleResponse(action Action, objects map[string]Object, flag string) map[string]interface{} {
+	return ExampleKeyValues(FilterReturns(action.Returns, flag), objects)
+}
+
+// Receive a list of key/values
+// Return a JSON-encodable value shaped like the object they describe.
+func ExampleKeyValues(keyValues []KeyValue, objects map[string]Object) map[string]interface{} {
+	return exampleKeyValues(keyValues, objects, make(map[string]bool))
+}
+
+func ExampleKeyValue(keyValue KeyValue, objects map[string]Object) interface{} {
+	return exampleKeyValue(keyValue, objects, make(map[string]bool))
+}
+
+// visited holds the refs of the objects currently being expanded - an object
+// that refers back to one of them is cut off with null.
+func exampleKeyValues(keyValues []KeyValue, objects map[string]Object, visited map[string]bool) map[string]interface{} {
+	example := make(map[string]interface{})
+
+	for _, keyValue := range keyValues {
+		example[keyValue.Name] = exampleKeyValue(keyValue, objects, visited)
+	}
+
+	return example
+}
+
+func exampleKeyValue(keyValue KeyValue, objects map[string]Object, visited map[string]bool) interface{} {
+	if len(keyValue.Example) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(keyValue.Example))
+		decoder.UseNumber()
+
+		var example interface{}
+
+		if err := decoder.Decode(&example); err == nil {
+			return example
+		}
+	}
+
+	if ref, ok := TypeRef(keyValue.Type); ok {
+		object, ok := objects[ref]
+
+		if !ok {
+			return map[string]interface{}{}
+		}
+
+		if visited[ref] {
+			return nil
+		}
+
+		visited[ref] = true
+		example := exampleKeyValues(object.Properties, objects, visited)
+		delete(visited, ref)
+
+		return example
+	}
+
+	switch keyValue.Type {
+	case "boolean":
+		return true
+	case "integer":
+		return 1
+	case "decimal":
+		if keyValue.Limit > 0 {
+			precision := math.Pow10(int(keyValue.Limit))
+			return math.Floor(1.2345*precision) / precision
+		}
+
+		return 1.5
+	case "string":
+		return ExampleString(keyValue.Name, keyValue.Limit)
+	case "object":
+		return exampleKeyValues(keyValue.Children, objects, visited)
+	case "array":
+		if len(keyValue.Children) == 0 {
+			return []interface{}{}
+		}
+
+		return []interface{}{exampleKeyValues(keyValue.Children, objects, visited)}
+	}
+
+	return nil
+}
+
+// Return an example of a path, query or header parameter as it would be sent
+// in a url or header.
+func ExampleLocationValue(keyValue KeyValue) string {
+	value, _ := json.Marshal(ExampleKeyValue(keyValue, map[string]Object{}))
+
+	return strings.Trim(string(value), "\"")
+}
+
+// Use the name of the key as the example, cut down to the limit.
+func ExampleString(name string, limit int64) string {
+	example := []rune(name)
+
+	if limit > 0 && int64(len(example)) > limit {
+		example = example[:limit]
+	}
+
+	return string(example)
+}
+
+// Return an indented sample JSON body for the body parameters of an action.
+func ExampleRequestBody(action Action, objects map[string]Object) (string, error) {
+	body, err := json.MarshalIndent(ExampleKeyValues(LocationParameters(action.Parameters, ""), objects), "", "  ")
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(body), nil
+}
@@ -0,0 +1,64 @@
+package atoz
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testExampleKeyValueCase struct {
+	keyValue KeyValue
+	example  interface{}
+}
+
+var testExampleObjects = ObjectsByRef([]Object{
+	Object{Name: "User", Ref: "/Application/User", Notes: []string{}, Properties: []KeyValue{
+		KeyValue{Name: "id", Type: "integer", Limit: -1, Children: []KeyValue{}},
+		KeyValue{Name: "manager", Type: "#/Application/User#", Limit: -1, Children: []KeyValue{}},
+	}},
+})
+
+var testExampleKeyValueCases = []testExampleKeyValueCase{
+	{
+		KeyValue{Name: "enabled", Type: "boolean", Limit: -1, Children: []KeyValue{}},
+		true,
+	},
+	{
+		KeyValue{Name: "price", Type: "decimal", Limit: 2, Children: []KeyValue{}},
+		1.23,
+	},
+	{
+		KeyValue{Name: "country", Type: "string", Limit: 2, Children: []KeyValue{}},
+		"co",
+	},
+	{
+		KeyValue{Name: "users", Type: "array", Limit: 10, Children: []KeyValue{
+			KeyValue{Name: "name", Type: "string", Children: []KeyValue{}},
+		}},
+		[]interface{}{
+			map[string]interface{}{"name": "name"},
+		},
+	},
+	{
+		KeyValue{Name: "user", Type: "#/Application/User#", Limit: -1, Children: []KeyValue{}},
+		map[string]interface{}{
+			"id":      1,
+			"manager": nil,
+		},
+	},
+	{
+		KeyValue{Name: "missing", Type: "#/Application/Missing#", Limit: -1, Children: []KeyValue{}},
+		map[string]interface{}{},
+	},
+}
+
+func TestExampleKeyValue(t *testing.T) {
+	var resultExample interface{}
+
+	for _, test := range testExampleKeyValueCases {
+		resultExample = ExampleKeyValue(test.keyValue, testExampleObjects)
+
+		if !reflect.DeepEqual(resultExample, test.example) {
+			t.Errorf("TestExampleKeyValue Mismatch: %s\nExpected: %v\n  Actual: %v", test.keyValue.Name, test.example, resultExample)
+		}
+	}
+}
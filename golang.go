@@ -0,0 +1,352 @@
+package atoz
+
+import (
+	"go/format"
+	"strconv"
+	"strings"
+)
+
+// Receive a spec, the title of the API and the name of the package to generate
+// Return the source of a Go package with a struct for every object, a Request
+// and Response struct for every action, and a Client with a method for every
+// action, formatted with go/format.
+func GenerateGo(apiSpec ApiSpec, title string, packageName string) ([]byte, error) {
+	generator := goGenerator{ObjectsByRef(apiSpec.Objects), make([]string, 0)}
+
+	for _, object := range apiSpec.Objects {
+		comment := goDocComment(TypeName(object.Ref), "is the "+object.Name+" object.", ObjectDescription(object), object.Deprecated)
+
+		generator.structType(TypeName(object.Ref), comment, object.Properties)
+	}
+
+	methods := make([]string, 0)
+
+	for _, action := range apiSpec.Actions {
+		name := TypeName(action.Ref)
+		actionTitle := action.Name
+
+		if len(actionTitle) == 0 {
+			actionTitle = action.Ref
+		}
+
+		summary := "is the body of the " + actionTitle + " request."
+
+		if len(LocationParameters(action.Parameters, "")) < len(action.Parameters) {
+			summary += "\nPath, query and header parameters are sent apart from the JSON body."
+		}
+
+		generator.structType(name+"Request", goDocComment(name+"Request", summary, "", action.Deprecated), action.Parameters)
+		generator.structType(name+"Response", goDocComment(name+"Response", "is the response to the "+actionTitle+" request.", "", action.Deprecated), ResponseReturns(action))
+
+		// There's nowhere to send an action without a uri.
+		if len(action.Uri) > 0 {
+			methods = append(methods, goClientMethod(action, actionTitle))
+		}
+	}
+
+	source := "// Code generated by atoz. DO NOT EDIT.\n\n" +
+		"// Package " + packageName + " is a client for " + title + ".\n" +
+		"package " + packageName + "\n\n" +
+		goClientSource +
+		strings.Join(generator.types, "\n") + "\n" +
+		strings.Join(methods, "\n")
+
+	return format.Source([]byte(source))
+}
+
+// Collects the declarations of the structs in the order they're found, with
+// each nested struct following the struct it's declared in.
+type goGenerator struct {
+	objects map[string]Object
+	types   []string
+}
+
+func (g *goGenerator) structType(name string, comment string, keyValues []KeyValue) {
+	index := len(g.types)
+	g.types = append(g.types, "")
+
+	fields := ""
+
+	for _, keyValue := range keyValues {
+		if len(keyValue.Description) > 0 {
+			fields += goComment(keyValue.Description)
+		}
+
+		tag := keyValue.Name
+
+		if len(keyValue.Location) > 0 {
+			tag = "-"
+		} else if isOptional(keyValue) {
+			tag += ",omitempty"
+		}
+
+		fields += GoFieldName(keyValue.Name) + " " + g.fieldType(name, keyValue) + " `json:\"" + tag + "\"`\n"
+	}
+
+	g.types[index] = comment + "type " + name + " struct {\n" + fields + "}\n"
+}
+
+// Objects and arrays with children become a struct named after the struct
+// they're in and their own name.  #Ref# types are always pointers, so objects
+// can refer back to themselves.
+func (g *goGenerator) fieldType(parent string, keyValue KeyValue) string {
+	var fieldType string
+
+	if ref, ok := TypeRef(keyValue.Type); ok {
+		if _, ok := g.objects[ref]; ok {
+			return "*" + TypeName(ref)
+		}
+
+		return "interface{}"
+	}
+
+	switch keyValue.Type {
+	case "boolean":
+		fieldType = "bool"
+	case "integer":
+		fieldType = "int64"
+	case "decimal":
+		fieldType = "float64"
+	case "string":
+		fieldType = "string"
+	case "object":
+		if len(keyValue.Children) == 0 {
+			return "map[string]interface{}"
+		}
+
+		fieldType = parent + GoFieldName(keyValue.Name)
+		g.structType(fieldType, goDocComment(fieldType, "is the "+keyValue.Name+" value of "+parent+".", "", false), keyValue.Children)
+	case "array":
+		if len(keyValue.Children) == 0 {
+			return "[]interface{}"
+		}
+
+		elementType := parent + GoFieldName(keyValue.Name)
+		g.structType(elementType, goDocComment(elementType, "is an element of the "+keyValue.Name+" value of "+parent+".", "", false), keyValue.Children)
+
+		return "[]" + elementType
+	default:
+		return "interface{}"
+	}
+
+	if isOptional(keyValue) {
+		return "*" + fieldType
+	}
+
+	return fieldType
+}
+
+// Receive
+// first-name
+// Return FirstName
+func GoFieldName(name string) string {
+	if fieldName := TypeName(name); len(fieldName) > 0 {
+		return fieldName
+	}
+
+	return "Field"
+}
+
+func goClientMethod(action Action, actionTitle string) string {
+	name := TypeName(action.Ref)
+
+	method := ActionMethod(action)
+
+	// The uri is split around its placeholders, with each placeholder filled
+	// in from the path parameter of the same name.
+	path := make([]string, 0)
+	last := 0
+
+	for _, match := range uriPlaceholder.FindAllStringSubmatchIndex(action.Uri, -1) {
+		parameter := FindKeyValue(LocationParameters(action.Parameters, "path"), action.Uri[match[2]:match[3]])
+
+		if parameter == nil {
+			continue
+		}
+
+		path = append(path, strconv.Quote(action.Uri[last:match[0]]), "url.PathEscape("+goLocationValue(*parameter)+")")
+		last = match[1]
+	}
+
+	path = append(path, strconv.Quote(action.Uri[last:]))
+
+	body := "\tpath := " + strings.Join(path, " + ") + "\n" +
+		"\tquery := url.Values{}\n" +
+		"\theader := http.Header{}\n"
+
+	for _, location := range []string{"query", "header"} {
+		for _, parameter := range LocationParameters(action.Parameters, location) {
+			set := "\t" + location + ".Set(" + strconv.Quote(parameter.Name) + ", " + goLocationValue(parameter) + ")\n"
+
+			if isOptional(parameter) {
+				set = "\tif request." + GoFieldName(parameter.Name) + " != nil {\n\t" + set + "\t}\n"
+			}
+
+			body += set
+		}
+	}
+
+	requestBody := "nil"
+
+	if len(LocationParameters(action.Parameters, "")) > 0 {
+		requestBody = "request"
+	}
+
+	body += "\tvar response " + name + "Response\n" +
+		"\terr := c.do(ctx, " + strconv.Quote(strings.ToUpper(method)) + ", path, query, header, " + requestBody + ", &response)\n" +
+		"\treturn &response, err\n"
+
+	comment := goDocComment(name, "sends the "+actionTitle+" request.\nThe response is returned along with an *Error when the status isn't 2xx.", ActionDescription(action), action.Deprecated)
+
+	return comment +
+		"func (c *Client) " + name + "(ctx context.Context, request " + name + "Request) (*" + name + "Response, error) {\n" +
+		body +
+		"}\n"
+}
+
+func goLocationValue(parameter KeyValue) string {
+	value := "request." + GoFieldName(parameter.Name)
+
+	if isOptional(parameter) {
+		value = "*" + value
+	}
+
+	return "fmt.Sprint(" + value + ")"
+}
+
+func goComment(text string) string {
+	comment := ""
+
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		comment += strings.TrimRight("// "+line, " ") + "\n"
+	}
+
+	return comment
+}
+
+// Go doc comments start with the name of what they document, and mark it as
+// deprecated in a paragraph of its own.
+func goDocComment(name string, summary string, description string, deprecated bool) string {
+	comment := goComment(name + " " + summary)
+
+	if len(description) > 0 {
+		comment += "//\n" + goComment(description)
+	}
+
+	if deprecated {
+		comment += "//\n// Deprecated: " + name + " is deprecated.\n"
+	}
+
+	return comment
+}
+
+const goClientSource = `import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Client sends requests to the API at BaseUrl.
+type Client struct {
+	BaseUrl string
+
+	// Sent with every request - i.e. an Authorization header.
+	Header http.Header
+
+	// http.DefaultClient when nil.
+	HttpClient *http.Client
+}
+
+func NewClient(baseUrl string) *Client {
+	return &Client{BaseUrl: baseUrl, Header: http.Header{}}
+}
+
+// Error is returned when the API responds with a status that isn't 2xx.
+type Error struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+// The response body is decoded into result whatever the status, so failure
+// values can be read along with the *Error.
+func (c *Client) do(ctx context.Context, method string, path string, query url.Values, header http.Header, body interface{}, result interface{}) error {
+	var reader io.Reader
+
+	if body != nil {
+		encoded, err := json.Marshal(body)
+
+		if err != nil {
+			return err
+		}
+
+		reader = bytes.NewReader(encoded)
+	}
+
+	uri := strings.TrimRight(c.BaseUrl, "/") + path
+
+	if len(query) > 0 {
+		uri += "?" + query.Encode()
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, uri, reader)
+
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	for name, values := range c.Header {
+		request.Header[name] = values
+	}
+
+	for name, values := range header {
+		request.Header[name] = values
+	}
+
+	httpClient := c.HttpClient
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	response, err := httpClient.Do(request)
+
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	contents, err := ioutil.ReadAll(response.Body)
+
+	if err != nil {
+		return err
+	}
+
+	if len(bytes.TrimSpace(contents)) > 0 {
+		err = json.Unmarshal(contents, result)
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return &Error{response.StatusCode, contents}
+	}
+
+	return err
+}
+
+`
@@ -0,0 +1,90 @@
+package atoz
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateGo(t *testing.T) {
+	apiSpec := ApiSpec{
+		Actions: []Action{
+			Action{Name: "Update User", Ref: "/MyApp/User/Update", Method: "PUT", Uri: "/users/{id}/profile", Notes: []string{}, Parameters: []KeyValue{
+				KeyValue{Name: "id", Flag: "required", Type: "integer", Limit: -1, Children: []KeyValue{}, Location: "path"},
+				KeyValue{Name: "fields", Flag: "optional", Type: "string", Children: []KeyValue{}, Location: "query"},
+				KeyValue{Name: "page", Type: "integer", Limit: -1, Children: []KeyValue{}, Location: "query"},
+				KeyValue{Name: "auth", Flag: "required", Type: "object", Limit: -1, Children: []KeyValue{
+					KeyValue{Name: "key", Flag: "required", Type: "string", Limit: 64, Description: "The key.", Children: []KeyValue{}},
+				}},
+				KeyValue{Name: "score", Flag: "optional", Type: "decimal", Limit: 2, Children: []KeyValue{}},
+			}, Returns: []KeyValue{
+				KeyValue{Name: "user", Flag: "success", Type: "#/Application/User#", Limit: -1, Children: []KeyValue{}, ResolvedRef: "/Application/User"},
+			}, Responses: map[string]Response{
+				"201": Response{Returns: []KeyValue{
+					KeyValue{Name: "created", Flag: "success", Type: "integer", Limit: -1, Children: []KeyValue{}},
+				}},
+			}},
+			Action{Name: "Internal", Ref: "/MyApp/Internal", Method: "POST", Notes: []string{}, Parameters: []KeyValue{}, Returns: []KeyValue{}},
+		},
+		Objects: []Object{
+			Object{Name: "User", Ref: "/Application/User", Notes: []string{}, Properties: []KeyValue{
+				KeyValue{Name: "first-name", Type: "string", Children: []KeyValue{}},
+				KeyValue{Name: "manager", Type: "#/Application/User#", Limit: -1, Children: []KeyValue{}, ResolvedRef: "/Application/User"},
+				KeyValue{Name: "groups", Type: "array", Children: []KeyValue{
+					KeyValue{Name: "name", Type: "string", Children: []KeyValue{}},
+				}},
+			}, Deprecated: true},
+		},
+	}
+
+	source, err := GenerateGo(apiSpec, "Test", "client")
+
+	if err != nil {
+		t.Errorf("TestGenerateGo Unexpected error: %s", err)
+		return
+	}
+
+	expected := []string{
+		"package client\n",
+		"// Deprecated: ApplicationUser is deprecated.\ntype ApplicationUser struct {\n" +
+			"\tFirstName string                  `json:\"first-name\"`\n" +
+			"\tManager   *ApplicationUser        `json:\"manager\"`\n" +
+			"\tGroups    []ApplicationUserGroups `json:\"groups\"`\n" +
+			"}\n",
+		"type ApplicationUserGroups struct {\n\tName string `json:\"name\"`\n}\n",
+		"type MyAppUserUpdateRequest struct {\n" +
+			"\tId     int64                      `json:\"-\"`\n" +
+			"\tFields *string                    `json:\"-\"`\n" +
+			"\tPage   *int64                     `json:\"-\"`\n" +
+			"\tAuth   MyAppUserUpdateRequestAuth `json:\"auth\"`\n" +
+			"\tScore  *float64                   `json:\"score,omitempty\"`\n" +
+			"}\n",
+		"type MyAppUserUpdateRequestAuth struct {\n\t// The key.\n\tKey string `json:\"key\"`\n}\n",
+		"type MyAppUserUpdateResponse struct {\n" +
+			"\tUser    *ApplicationUser `json:\"user,omitempty\"`\n" +
+			"\tCreated *int64           `json:\"created,omitempty\"`\n" +
+			"}\n",
+		"func (c *Client) MyAppUserUpdate(ctx context.Context, request MyAppUserUpdateRequest) (*MyAppUserUpdateResponse, error) {\n" +
+			"\tpath := \"/users/\" + url.PathEscape(fmt.Sprint(request.Id)) + \"/profile\"\n" +
+			"\tquery := url.Values{}\n" +
+			"\theader := http.Header{}\n" +
+			"\tif request.Fields != nil {\n" +
+			"\t\tquery.Set(\"fields\", fmt.Sprint(*request.Fields))\n" +
+			"\t}\n" +
+			"\tif request.Page != nil {\n" +
+			"\t\tquery.Set(\"page\", fmt.Sprint(*request.Page))\n" +
+			"\t}\n" +
+			"\tvar response MyAppUserUpdateResponse\n" +
+			"\terr := c.do(ctx, \"PUT\", path, query, header, request, &response)\n",
+		"type MyAppInternalRequest struct {\n}\n",
+	}
+
+	for _, snippet := range expected {
+		if !strings.Contains(string(source), snippet) {
+			t.Errorf("TestGenerateGo Missing:\n%s\nFrom:\n%s", snippet, source)
+		}
+	}
+
+	if strings.Contains(string(source), "func (c *Client) MyAppInternal(") {
+		t.Errorf("TestGenerateGo Generated a method for an action without a uri")
+	}
+}
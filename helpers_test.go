@@ -0,0 +1,33 @@
+package atoz
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Write each source to the file of the same name under a new temporary
+// directory, and return the directory - which the caller removes.
+func writeSpecFiles(t *testing.T, sources map[string]string) string {
+	dir, err := ioutil.TempDir("", "atoz")
+
+	if err != nil {
+		t.Fatalf("writeSpecFiles Unexpected error: %s", err)
+	}
+
+	for name, contents := range sources {
+		file := filepath.Join(dir, name)
+
+		if err = os.MkdirAll(filepath.Dir(file), 0755); err == nil {
+			err = ioutil.WriteFile(file, []byte(contents), 0644)
+		}
+
+		if err != nil {
+			os.RemoveAll(dir)
+			t.Fatalf("writeSpecFiles Unexpected error: %s", err)
+		}
+	}
+
+	return dir
+}
@@ -0,0 +1,366 @@
+package atoz
+
+import (
+	"bytes"
+	"html/template"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+type HtmlPage struct {
+	Title   string
+	Root    string
+	Actions []HtmlLink
+	Objects []HtmlLink
+	Action  *HtmlAction
+	Object  *HtmlObject
+}
+
+type HtmlLink struct {
+	Name string
+	Ref  string
+	Href string
+}
+
+type HtmlAction struct {
+	Name        string
+	Ref         string
+	Method      string
+	Uri         string
+	Description string
+	Notes       []string
+	Parameters  []HtmlKeyValue
+	Returns     []HtmlKeyValue
+	Responses   []HtmlResponse
+}
+
+type HtmlResponse struct {
+	Status      string
+	Description string
+	Returns     []HtmlKeyValue
+}
+
+type HtmlObject struct {
+	Name        string
+	Ref         string
+	Description string
+	Notes       []string
+	Properties  []HtmlKeyValue
+}
+
+type HtmlKeyValue struct {
+	Name        string
+	Path        string
+	Anchor      string
+	Class       string
+	Flag        string
+	Location    string
+	Type        string
+	TypeHref    string
+	Limit       int64
+	Description string
+	Children    []HtmlKeyValue
+}
+
+// Every template can be replaced by placing a file with the same name and an
+// .html extension in the template directory - i.e. action.html.  The stylesheet
+// can be replaced with a style.css.
+var defaultHtmlTemplates = map[string]string{
+	"layout": `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{if .Action}}{{.Action.Name}} - {{else if .Object}}{{.Object.Name}} - {{end}}{{.Title}}</title>
+<link rel="stylesheet" href="{{.Root}}style.css">
+</head>
+<body>
+<nav class="sidebar">
+<h1><a href="{{.Root}}index.html">{{.Title}}</a></h1>
+<h2>Actions</h2>
+<ul>{{range .Actions}}
+<li><a href="{{.Href}}">{{.Name}}</a></li>{{end}}
+</ul>
+<h2>Objects</h2>
+<ul>{{range .Objects}}
+<li><a href="{{.Href}}">{{.Name}}</a></li>{{end}}
+</ul>
+</nav>
+<main class="content">
+{{template "content" .}}
+</main>
+</body>
+</html>
+`,
+	"keyvalues": `{{if .}}<ul class="keyvalues">{{range .}}
+<li id="{{.Anchor}}" class="{{.Class}}">
+<a class="anchor" href="#{{.Anchor}}">{{.Name}}</a>
+<span class="type">{{if .TypeHref}}<a href="{{.TypeHref}}">{{.Type}}</a>{{else}}{{.Type}}{{end}}{{if gt .Limit 0}}, {{.Limit}}{{end}}</span>
+{{if .Flag}}<span class="flag">{{.Flag}}</span>{{end}}
+{{if .Location}}<span class="location">{{.Location}}</span>{{end}}
+{{if .Description}}<span class="description">{{.Description}}</span>{{end}}
+{{template "keyvalues" .Children}}
+</li>{{end}}
+</ul>{{end}}`,
+	"index": `<h1>{{.Title}}</h1>
+<h2>Actions</h2>
+<dl>{{range .Actions}}
+<dt><a href="{{.Href}}">{{.Name}}</a></dt>
+<dd>{{.Ref}}</dd>{{end}}
+</dl>
+<h2>Objects</h2>
+<dl>{{range .Objects}}
+<dt><a href="{{.Href}}">{{.Name}}</a></dt>
+<dd>{{.Ref}}</dd>{{end}}
+</dl>
+`,
+	"action": `{{with .Action}}<h1>{{.Name}}</h1>
+<p class="ref">{{.Ref}}</p>
+<p class="uri"><code><span class="method">{{.Method}}</span> {{.Uri}}</code></p>
+{{if .Description}}<p class="description">{{.Description}}</p>{{end}}
+{{if .Notes}}<ul class="notes">{{range .Notes}}
+<li>{{.}}</li>{{end}}
+</ul>{{end}}
+<h2>Parameters</h2>
+{{template "keyvalues" .Parameters}}
+{{if .Responses}}{{range .Responses}}
+<h2 id="response-{{.Status}}">Returns <span class="status">{{.Status}}</span></h2>
+{{if .Description}}<p class="description">{{.Description}}</p>{{end}}
+{{template "keyvalues" .Returns}}
+{{end}}{{else}}<h2>Returns</h2>
+{{template "keyvalues" .Returns}}
+{{end}}{{end}}`,
+	"object": `{{with .Object}}<h1>{{.Name}}</h1>
+<p class="ref">{{.Ref}}</p>
+{{if .Description}}<p class="description">{{.Description}}</p>{{end}}
+{{if .Notes}}<ul class="notes">{{range .Notes}}
+<li>{{.}}</li>{{end}}
+</ul>{{end}}
+<h2>Properties</h2>
+{{template "keyvalues" .Properties}}
+{{end}}`,
+}
+
+const defaultHtmlStyle = `body { display: flex; margin: 0; font-family: sans-serif; }
+.sidebar { width: 16em; padding: 1em; background: #f4f4f4; min-height: 100vh; }
+.content { flex: 1; padding: 1em 2em; }
+.keyvalues { list-style: none; padding-left: 1.5em; }
+.keyvalues li { margin: 0.25em 0; }
+.type { color: #666; }
+.flag { font-size: 0.8em; padding: 0 0.4em; border-radius: 0.3em; }
+.flag-required .flag { background: #fdd; }
+.flag-optional .flag { background: #eee; }
+.flag-success .flag { background: #dfd; }
+.flag-failure .flag { background: #fdd; }
+.location { font-size: 0.8em; padding: 0 0.4em; border-radius: 0.3em; background: #def; }
+`
+
+// Receive a directory that may contain template overrides, or a blank string
+// Return the templates to render with, keyed by name.
+func LoadHtmlTemplates(dir string) (map[string]string, error) {
+	templates := make(map[string]string)
+
+	for name, contents := range defaultHtmlTemplates {
+		templates[name] = contents
+	}
+
+	templates["style"] = defaultHtmlStyle
+
+	if len(dir) == 0 {
+		return templates, nil
+	}
+
+	for name, _ := range templates {
+		file := name + ".html"
+
+		if name == "style" {
+			file = "style.css"
+		}
+
+		contents, err := ioutil.ReadFile(filepath.Join(dir, file))
+
+		if os.IsNotExist(err) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		templates[name] = string(contents)
+	}
+
+	return templates, nil
+}
+
+func HtmlActionFile(ref string) string {
+	return "actions/" + SchemaName(ref) + ".html"
+}
+
+func HtmlObjectFile(ref string) string {
+	return "objects/" + SchemaName(ref) + ".html"
+}
+
+// Return every file of the site keyed by its path relative to the output
+// directory.
+func GenerateHtml(apiSpec ApiSpec, title string, templates map[string]string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+
+	var err error
+
+	pages := make(map[string]*template.Template)
+
+	for _, page := range []string{"index", "action", "object"} {
+		pages[page], err = parseHtmlTemplate(templates, page)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files["style.css"] = []byte(templates["style"])
+
+	files["index.html"], err = renderHtmlPage(pages["index"], newHtmlPage(apiSpec, title, ""))
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, action := range apiSpec.Actions {
+		page := newHtmlPage(apiSpec, title, "../")
+		page.Action = &HtmlAction{
+			action.Name,
+			action.Ref,
+			ActionMethod(action),
+			action.Uri,
+			action.Description,
+			action.Notes,
+			HtmlKeyValues(action.Parameters, "parameter-", "", page.Root),
+			HtmlKeyValues(action.Returns, "return-", "", page.Root),
+			make([]HtmlResponse, 0),
+		}
+
+		for _, status := range ResponseStatuses(action) {
+			page.Action.Responses = append(page.Action.Responses, HtmlResponse{
+				status,
+				action.Responses[status].Description,
+				HtmlKeyValues(action.Responses[status].Returns, "return-"+status+"-", "", page.Root),
+			})
+		}
+
+		files[HtmlActionFile(action.Ref)], err = renderHtmlPage(pages["action"], page)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, object := range apiSpec.Objects {
+		page := newHtmlPage(apiSpec, title, "../")
+		page.Object = &HtmlObject{
+			object.Name,
+			object.Ref,
+			object.Description,
+			object.Notes,
+			HtmlKeyValues(object.Properties, "property-", "", page.Root),
+		}
+
+		files[HtmlObjectFile(object.Ref)], err = renderHtmlPage(pages["object"], page)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+// Builds the renderable tree of key/values - each is given an anchor built
+// from its full object.space so nested values can be linked to directly.
+func HtmlKeyValues(keyValues []KeyValue, anchorPrefix string, objectspace string, root string) []HtmlKeyValue {
+	htmlKeyValues := make([]HtmlKeyValue, 0)
+
+	for _, keyValue := range keyValues {
+		htmlKeyValue := HtmlKeyValue{
+			Name:        keyValue.Name,
+			Path:        objectspace + keyValue.Name,
+			Anchor:      anchorPrefix + objectspace + keyValue.Name,
+			Class:       "keyvalue",
+			Flag:        keyValue.Flag,
+			Location:    keyValue.Location,
+			Type:        keyValue.Type,
+			Limit:       keyValue.Limit,
+			Description: keyValue.Description,
+		}
+
+		if len(keyValue.Flag) > 0 {
+			htmlKeyValue.Class += " flag-" + keyValue.Flag
+		}
+
+		if len(keyValue.Location) > 0 {
+			htmlKeyValue.Class += " location-" + keyValue.Location
+		}
+
+		if ref, ok := TypeRef(keyValue.Type); ok {
+			htmlKeyValue.Type = ref
+			htmlKeyValue.TypeHref = root + HtmlObjectFile(ref)
+		}
+
+		htmlKeyValue.Children = HtmlKeyValues(keyValue.Children, anchorPrefix, htmlKeyValue.Path+".", root)
+
+		htmlKeyValues = append(htmlKeyValues, htmlKeyValue)
+	}
+
+	return htmlKeyValues
+}
+
+func newHtmlPage(apiSpec ApiSpec, title string, root string) *HtmlPage {
+	page := &HtmlPage{
+		Title:   title,
+		Root:    root,
+		Actions: make([]HtmlLink, 0),
+		Objects: make([]HtmlLink, 0),
+	}
+
+	for _, action := range apiSpec.Actions {
+		page.Actions = append(page.Actions, HtmlLink{action.Name, action.Ref, root + HtmlActionFile(action.Ref)})
+	}
+
+	for _, object := range apiSpec.Objects {
+		page.Objects = append(page.Objects, HtmlLink{object.Name, object.Ref, root + HtmlObjectFile(object.Ref)})
+	}
+
+	return page
+}
+
+func parseHtmlTemplate(templates map[string]string, page string) (*template.Template, error) {
+	t, err := template.New("layout").Parse(templates["layout"])
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = t.New("keyvalues").Parse(templates["keyvalues"])
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = t.New("content").Parse(templates[page])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func renderHtmlPage(t *template.Template, page *HtmlPage) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	if err := t.ExecuteTemplate(&buffer, "layout", page); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,93 @@
+package atoz
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestHtmlKeyValues(t *testing.T) {
+	keyValues := []KeyValue{
+		KeyValue{Name: "auth", Flag: "required", Type: "object", Limit: -1, Children: []KeyValue{
+			KeyValue{Name: "id", Type: "integer", Limit: -1, Children: []KeyValue{}},
+		}},
+		KeyValue{Name: "user", Flag: "success", Type: "#/Application/User#", Limit: -1, Children: []KeyValue{}},
+	}
+
+	expected := []HtmlKeyValue{
+		HtmlKeyValue{
+			Name:   "auth",
+			Path:   "auth",
+			Anchor: "parameter-auth",
+			Class:  "keyvalue flag-required",
+			Flag:   "required",
+			Type:   "object",
+			Limit:  -1,
+			Children: []HtmlKeyValue{
+				HtmlKeyValue{
+					Name:     "id",
+					Path:     "auth.id",
+					Anchor:   "parameter-auth.id",
+					Class:    "keyvalue",
+					Type:     "integer",
+					Limit:    -1,
+					Children: []HtmlKeyValue{},
+				},
+			},
+		},
+		HtmlKeyValue{
+			Name:     "user",
+			Path:     "user",
+			Anchor:   "parameter-user",
+			Class:    "keyvalue flag-success",
+			Flag:     "success",
+			Type:     "/Application/User",
+			TypeHref: "../objects/Application.User.html",
+			Limit:    -1,
+			Children: []HtmlKeyValue{},
+		},
+	}
+
+	result := HtmlKeyValues(keyValues, "parameter-", "", "../")
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("TestHtmlKeyValues Mismatch\nExpected: %+v\n  Actual: %+v", expected, result)
+	}
+}
+
+func TestGenerateHtml(t *testing.T) {
+	apiSpec := ApiSpec{
+		Actions: []Action{
+			Action{Name: "User Lookup", Ref: "/MyApp/User/Lookup", Method: "POST", Uri: "/User/Lookup", Notes: []string{}, Parameters: []KeyValue{}, Returns: []KeyValue{}},
+		},
+		Objects: []Object{
+			Object{Name: "User", Ref: "/Application/User", Notes: []string{}, Properties: []KeyValue{}},
+		},
+	}
+
+	templates, err := LoadHtmlTemplates("")
+
+	if err != nil {
+		t.Errorf("TestGenerateHtml Unexpected error: %s", err)
+		return
+	}
+
+	templates["action"] = `<p class="custom">{{.Action.Uri}}</p>`
+
+	files, err := GenerateHtml(apiSpec, "Test", templates)
+
+	if err != nil {
+		t.Errorf("TestGenerateHtml Unexpected error: %s", err)
+		return
+	}
+
+	for _, name := range []string{"index.html", "style.css", "actions/MyApp.User.Lookup.html", "objects/Application.User.html"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("TestGenerateHtml Missing file: %s", name)
+		}
+	}
+
+	if !bytes.Contains(files["actions/MyApp.User.Lookup.html"], []byte(`<p class="custom">/User/Lookup</p>`)) {
+		t.Errorf("TestGenerateHtml Template override not used:\n%s", files["actions/MyApp.User.Lookup.html"])
+	}
+}
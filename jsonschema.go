@@ -0,0 +1,87 @@
+package atoz
+
+import (
+	"encoding/json"
+)
+
+const jsonSchemaVersion = "https://json-schema.org/draft/2020-12/schema"
+
+// Each object is written to its own file, so #Ref# types point at the file
+// of the referenced object.
+func jsonSchemaRefPath(ref string) string {
+	return JsonSchemaObjectFile(ref)
+}
+
+func JsonSchemaObjectFile(ref string) string {
+	return SchemaName(ref) + ".json"
+}
+
+func JsonSchemaRequestFile(ref string) string {
+	return SchemaName(ref) + ".request.json"
+}
+
+func JsonSchemaResponseFile(ref string) string {
+	return SchemaName(ref) + ".response.json"
+}
+
+func JsonSchemaStatusResponseFile(ref string, status string) string {
+	return SchemaName(ref) + ".response." + status + ".json"
+}
+
+// Return a JSON Schema document for every object, and a request and response
+// document for every action, keyed by file name.
+func GenerateJsonSchemas(apiSpec ApiSpec) map[string]*Schema {
+	schemas := make(map[string]*Schema)
+
+	var schema *Schema
+
+	for _, object := range apiSpec.Objects {
+		schema = KeyValuesSchema(object.Properties, jsonSchemaRefPath)
+		schema.SchemaVersion = jsonSchemaVersion
+		schema.Title = object.Name
+		schema.Description = ObjectDescription(object)
+
+		schemas[JsonSchemaObjectFile(object.Ref)] = schema
+	}
+
+	for _, action := range apiSpec.Actions {
+		schema = KeyValuesSchema(LocationParameters(action.Parameters, ""), jsonSchemaRefPath)
+		schema.SchemaVersion = jsonSchemaVersion
+		schema.Title = action.Name + " Request"
+
+		schemas[JsonSchemaRequestFile(action.Ref)] = schema
+
+		schema = KeyValuesSchema(action.Returns, jsonSchemaRefPath)
+		schema.SchemaVersion = jsonSchemaVersion
+		schema.Title = action.Name + " Response"
+
+		schemas[JsonSchemaResponseFile(action.Ref)] = schema
+
+		for status, response := range action.Responses {
+			schema = KeyValuesSchema(response.Returns, jsonSchemaRefPath)
+			schema.SchemaVersion = jsonSchemaVersion
+			schema.Title = action.Name + " " + status + " Response"
+			schema.Description = response.Description
+
+			schemas[JsonSchemaStatusResponseFile(action.Ref, status)] = schema
+		}
+	}
+
+	return schemas
+}
+
+func GenerateJsonSchemaFiles(apiSpec ApiSpec) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+
+	for name, schema := range GenerateJsonSchemas(apiSpec) {
+		schemaJson, err := json.Marshal(schema)
+
+		if err != nil {
+			return nil, err
+		}
+
+		files[name] = schemaJson
+	}
+
+	return files, nil
+}
@@ -0,0 +1,67 @@
+package atoz
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGenerateJsonSchemas(t *testing.T) {
+	apiSpec := ApiSpec{
+		Actions: []Action{
+			Action{
+				Name:   "User Lookup",
+				Ref:    "/MyApp/User/Lookup",
+				Method: "POST",
+				Uri:    "/User/Lookup",
+				Notes:  []string{},
+				Parameters: []KeyValue{
+					KeyValue{Name: "id", Flag: "required", Type: "integer", Limit: -1, Children: []KeyValue{}},
+				},
+				Returns: []KeyValue{
+					KeyValue{Name: "user", Type: "#/Application/User#", Limit: -1, Children: []KeyValue{}},
+				},
+			},
+		},
+		Objects: []Object{
+			Object{
+				Name:  "User",
+				Ref:   "/Application/User",
+				Notes: []string{},
+				Properties: []KeyValue{
+					KeyValue{Name: "id", Type: "integer", Limit: -1, Children: []KeyValue{}},
+				},
+			},
+		},
+	}
+
+	schemas := GenerateJsonSchemas(apiSpec)
+
+	names := make([]string, 0)
+
+	for name, _ := range schemas {
+		names = append(names, name)
+	}
+
+	if len(schemas) != 3 {
+		t.Errorf("TestGenerateJsonSchemas Expected 3 schemas, got: %s", names)
+		return
+	}
+
+	request := schemas["MyApp.User.Lookup.request.json"]
+
+	if request == nil || !reflect.DeepEqual(request.Required, []string{"id"}) {
+		t.Errorf("TestGenerateJsonSchemas Request Mismatch: %+v", request)
+	}
+
+	response := schemas["MyApp.User.Lookup.response.json"]
+
+	if response == nil || response.Properties["user"].Ref != "Application.User.json" {
+		t.Errorf("TestGenerateJsonSchemas Response Mismatch: %+v", response)
+	}
+
+	object := schemas["Application.User.json"]
+
+	if object == nil || object.SchemaVersion != jsonSchemaVersion || object.Title != "User" {
+		t.Errorf("TestGenerateJsonSchemas Object Mismatch: %+v", object)
+	}
+}
@@ -0,0 +1,323 @@
+package atoz
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// A group of lines along with its type and ref, as read by the linter.  Start
+// is the ---ATOZ*--- line that opened the group.
+type LintGroup struct {
+	Type  string
+	Ref   string
+	Start Line
+	Lines []Line
+}
+
+type LintRule struct {
+	Id          string
+	Description string
+	Check       func(groups []LintGroup, apiSpec ApiSpec) Diagnostics
+}
+
+// Every rule reports warnings - errors come from parsing the files.
+var LintRules = []LintRule{
+	LintRule{"orphan-child", "Children whose parent object.space was never declared.", lintOrphanChildren},
+	LintRule{"missing-uri", "Actions without a @uri.", lintMissingUri},
+	LintRule{"missing-name", "Actions and objects without a @name.", lintMissingName},
+	LintRule{"unused-definition", "Definitions that are never included.", lintUnusedDefinitions},
+	LintRule{"duplicate-ref", "Actions, objects or definitions sharing a @ref.", lintDuplicateRefs},
+	LintRule{"unknown-ref", "{#/Ref#} types that point at no object.", lintUnknownRefs},
+}
+
+// Receive the files to lint and the IDs of rules to skip
+// Return the parse errors along with the warnings of every enabled rule.
+func Lint(files []string, disabled map[string]bool) (Diagnostics, error) {
+	var diagnostics Diagnostics
+
+	for id, _ := range disabled {
+		if FindLintRule(id) == nil {
+			return nil, fmt.Errorf("Unknown lint rule: %s", id)
+		}
+	}
+
+	// Unknown refs are left to the unknown-ref rule so they can be disabled.
+	apiSpec, err := Parser{Lax: true, Sources: true}.Parse(files)
+
+	if parsed, ok := err.(Diagnostics); ok {
+		for _, diagnostic := range parsed {
+			if diagnostic.Code != CodeUnknownRef {
+				diagnostics = diagnostics.Append(diagnostic.Code, diagnostic)
+			}
+		}
+	} else {
+		diagnostics = diagnostics.Append(CodeInvalidLine, err)
+	}
+
+	groups, _ := ReadGroups(files)
+
+	lintGroups := NewLintGroups(groups)
+
+	for _, rule := range LintRules {
+		if disabled[rule.Id] {
+			continue
+		}
+
+		for _, diagnostic := range rule.Check(lintGroups, apiSpec) {
+			diagnostics = diagnostics.Append(rule.Id, diagnostic)
+		}
+	}
+
+	sort.Stable(DiagnosticBySource(diagnostics))
+
+	return diagnostics, nil
+}
+
+func FindLintRule(id string) *LintRule {
+	for i, _ := range LintRules {
+		if LintRules[i].Id == id {
+			return &LintRules[i]
+		}
+	}
+
+	return nil
+}
+
+// Groups without a valid type or ref are left out - they're already reported
+// as errors.
+func NewLintGroups(groups [][]Line) []LintGroup {
+	lintGroups := make([]LintGroup, 0)
+
+	for _, group := range groups {
+		groupType, err := ParseGroupType(group[0].Text)
+
+		if err != nil {
+			continue
+		}
+
+		lines := group[1 : len(group)-1]
+
+		groupRef, err := ParseGroupRef(lines)
+
+		if err != nil {
+			continue
+		}
+
+		lintGroups = append(lintGroups, LintGroup{groupType, groupRef, group[0], lines})
+	}
+
+	return lintGroups
+}
+
+func lintWarning(line Line, id string, format string, args ...interface{}) Diagnostic {
+	return Diagnostic{line.Source.File, line.Source.Line, LineColumn(line.Text), SeverityWarning, id, fmt.Sprintf(format, args...)}
+}
+
+// Return the lines of a group with the lines of every definition it includes.
+func lintExpandGroup(group LintGroup, definitions map[string]LintGroup, visited map[string]bool) []Line {
+	lines := make([]Line, 0)
+
+	for _, line := range group.Lines {
+		lines = append(lines, line)
+
+		if lineType, err := ParseLineType(line.Text); err != nil || lineType != "include" {
+			continue
+		}
+
+		defRef, err := ParseLineString(line.Text)
+
+		if err != nil || visited[defRef] {
+			continue
+		}
+
+		if definition, ok := definitions[defRef]; ok {
+			visited[defRef] = true
+			lines = append(lines, lintExpandGroup(definition, definitions, visited)...)
+		}
+	}
+
+	return lines
+}
+
+func lintDefinitions(groups []LintGroup) map[string]LintGroup {
+	definitions := make(map[string]LintGroup)
+
+	for _, group := range groups {
+		if group.Type == "definition" {
+			definitions[group.Ref] = group
+		}
+	}
+
+	return definitions
+}
+
+// GenerateKeyValues only builds children under a parent it has already found,
+// so a child of an undeclared parent never shows up in the output.
+func lintOrphanChildren(groups []LintGroup, apiSpec ApiSpec) Diagnostics {
+	var diagnostics Diagnostics
+
+	definitions := lintDefinitions(groups)
+
+	for _, group := range groups {
+		if group.Type == "definition" {
+			continue
+		}
+
+		lines := lintExpandGroup(group, definitions, map[string]bool{})
+
+		declared := make(map[string]bool)
+
+		for _, line := range lines {
+			lineType, err := ParseLineType(line.Text)
+
+			if err != nil {
+				continue
+			}
+
+			if _, _, _, objectspace, _, err := ParseLineKeyValue(line.Text); err == nil {
+				declared[lineType+" "+objectspace] = true
+			}
+		}
+
+		for _, line := range lines {
+			lineType, err := ParseLineType(line.Text)
+
+			if err != nil || (lineType != "parameter" && lineType != "return" && lineType != "property") {
+				continue
+			}
+
+			_, _, _, objectspace, _, err := ParseLineKeyValue(line.Text)
+
+			if err != nil || strings.LastIndex(objectspace, ".") < 0 {
+				continue
+			}
+
+			parent := objectspace[:strings.LastIndex(objectspace, ".")]
+
+			if !declared[lineType+" "+parent] {
+				diagnostics = append(diagnostics, lintWarning(line, "orphan-child", "The parent %s of %s was never declared in %s, so it will be dropped", parent, objectspace, group.Ref))
+			}
+		}
+	}
+
+	return diagnostics
+}
+
+func lintMissingUri(groups []LintGroup, apiSpec ApiSpec) Diagnostics {
+	var diagnostics Diagnostics
+
+	for _, group := range groups {
+		if group.Type == "action" && !lintHasLineType(group.Lines, "uri") {
+			diagnostics = append(diagnostics, lintWarning(group.Start, "missing-uri", "Action %s has no @uri", group.Ref))
+		}
+	}
+
+	return diagnostics
+}
+
+func lintMissingName(groups []LintGroup, apiSpec ApiSpec) Diagnostics {
+	var diagnostics Diagnostics
+
+	for _, group := range groups {
+		if group.Type != "definition" && !lintHasLineType(group.Lines, "name") {
+			diagnostics = append(diagnostics, lintWarning(group.Start, "missing-name", "The %s %s has no @name", group.Type, group.Ref))
+		}
+	}
+
+	return diagnostics
+}
+
+func lintHasLineType(lines []Line, lineType string) bool {
+	for _, line := range lines {
+		if t, err := ParseLineType(line.Text); err == nil && t == lineType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func lintUnusedDefinitions(groups []LintGroup, apiSpec ApiSpec) Diagnostics {
+	var diagnostics Diagnostics
+
+	included := make(map[string]bool)
+
+	for _, group := range groups {
+		for _, line := range group.Lines {
+			if lineType, err := ParseLineType(line.Text); err == nil && lineType == "include" {
+				if defRef, err := ParseLineString(line.Text); err == nil {
+					included[defRef] = true
+				}
+			}
+		}
+	}
+
+	for _, group := range groups {
+		if group.Type == "definition" && !included[group.Ref] {
+			diagnostics = append(diagnostics, lintWarning(group.Start, "unused-definition", "Definition %s is never included", group.Ref))
+		}
+	}
+
+	return diagnostics
+}
+
+// Groups are stored by ref, so the later of two groups with the same ref
+// replaces the earlier one.
+func lintDuplicateRefs(groups []LintGroup, apiSpec ApiSpec) Diagnostics {
+	var diagnostics Diagnostics
+
+	seen := make(map[string]Line)
+
+	for _, group := range groups {
+		key := group.Type + " " + group.Ref
+
+		if first, ok := seen[key]; ok {
+			diagnostics = append(diagnostics, lintWarning(group.Start, "duplicate-ref", "The %s %s was already declared at %s", group.Type, group.Ref, first.Source))
+			continue
+		}
+
+		seen[key] = group.Start
+	}
+
+	return diagnostics
+}
+
+func lintUnknownRefs(groups []LintGroup, apiSpec ApiSpec) Diagnostics {
+	var diagnostics Diagnostics
+
+	var check func(keyValues []KeyValue, owner string)
+
+	check = func(keyValues []KeyValue, owner string) {
+		for _, keyValue := range keyValues {
+			if ref, ok := TypeRef(keyValue.Type); ok {
+				if len(keyValue.ResolvedRef) == 0 {
+					line := Line{"", Source{}}
+
+					if keyValue.Source != nil {
+						line.Source = *keyValue.Source
+					}
+
+					diagnostics = append(diagnostics, lintWarning(line, "unknown-ref", "%s in %s refers to %s, which is not an object", keyValue.Name, owner, ref))
+				}
+			}
+
+			check(keyValue.Children, owner)
+		}
+	}
+
+	for _, action := range apiSpec.Actions {
+		check(action.Parameters, action.Ref)
+		check(action.Returns, action.Ref)
+
+		for _, status := range ResponseStatuses(action) {
+			check(action.Responses[status].Returns, action.Ref)
+		}
+	}
+
+	for _, object := range apiSpec.Objects {
+		check(object.Properties, object.Ref)
+	}
+
+	return diagnostics
+}
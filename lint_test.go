@@ -0,0 +1,97 @@
+package atoz
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testLintSource = `/**
+ * ---ATOZDEF---
+ * @ref /Auth
+ * @parameter {Object} auth
+ * @parameter {Integer} auth.id
+ * ---ATOZEND---
+ * ---ATOZDEF---
+ * @ref /Unused
+ * ---ATOZEND---
+ * ---ATOZAPI---
+ * @ref /MyApp/User/Lookup
+ * @include /Auth
+ * @parameter {String} auth.key
+ * @parameter {String} filter.name
+ * @return {#/Application/Missing#} user
+ * ---ATOZEND---
+ * ---ATOZOBJ---
+ * @name User
+ * @ref /Application/User
+ * ---ATOZEND---
+ * ---ATOZOBJ---
+ * @name User Again
+ * @ref /Application/User
+ * ---ATOZEND---
+ */
+`
+
+type testLintCase struct {
+	disabled map[string]bool
+	result   []string
+}
+
+var testLintCases = []testLintCase{
+	{
+		map[string]bool{},
+		[]string{
+			"7 unused-definition",
+			"10 missing-uri",
+			"10 missing-name",
+			"14 orphan-child",
+			"15 unknown-ref",
+			"21 duplicate-ref",
+		},
+	},
+	{
+		map[string]bool{"missing-uri": true, "missing-name": true, "duplicate-ref": true},
+		[]string{
+			"7 unused-definition",
+			"14 orphan-child",
+			"15 unknown-ref",
+		},
+	},
+}
+
+func TestLint(t *testing.T) {
+	dir := writeSpecFiles(t, map[string]string{"user.js": testLintSource})
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "user.js")
+
+	for _, test := range testLintCases {
+		diagnostics, err := Lint([]string{file}, test.disabled)
+
+		if err != nil {
+			t.Errorf("TestLint Unexpected error: %s", err)
+			return
+		}
+
+		result := make([]string, 0)
+
+		for _, diagnostic := range diagnostics {
+			if diagnostic.Severity != SeverityWarning {
+				t.Errorf("TestLint Unexpected error: %s", diagnostic)
+			}
+
+			result = append(result, fmt.Sprintf("%d %s", diagnostic.Line, diagnostic.Code))
+		}
+
+		if !reflect.DeepEqual(result, test.result) {
+			t.Errorf("TestLint Mismatch: %v\nExpected: %s\n  Actual: %s", test.disabled, test.result, result)
+		}
+	}
+
+	if _, err := Lint([]string{file}, map[string]bool{"no-such-rule": true}); err == nil {
+		t.Errorf("TestLint - Should have errored out on an unknown rule")
+	}
+}
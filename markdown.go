@@ -0,0 +1,202 @@
+package atoz
+
+import (
+	"strconv"
+	"strings"
+)
+
+func MarkdownObjectAnchor(ref string) string {
+	return "object-" + SchemaName(ref)
+}
+
+func MarkdownActionAnchor(ref string) string {
+	return "action-" + SchemaName(ref)
+}
+
+func MarkdownActionFile(ref string) string {
+	return "actions/" + SchemaName(ref) + ".md"
+}
+
+func MarkdownObjectFile(ref string) string {
+	return "objects/" + SchemaName(ref) + ".md"
+}
+
+// Renders every action and object into a single document, with #Ref# types
+// linking to the object's section.
+func GenerateMarkdown(apiSpec ApiSpec, title string) []byte {
+	objectLink := func(ref string) string {
+		return "#" + MarkdownObjectAnchor(ref)
+	}
+
+	actionLink := func(ref string) string {
+		return "#" + MarkdownActionAnchor(ref)
+	}
+
+	markdown := MarkdownIndex(apiSpec, title, actionLink, objectLink)
+
+	if len(apiSpec.Actions) > 0 {
+		markdown += "\n## Actions\n"
+
+		for _, action := range apiSpec.Actions {
+			markdown += "\n" + MarkdownAction(action, "###", objectLink)
+		}
+	}
+
+	if len(apiSpec.Objects) > 0 {
+		markdown += "\n## Objects\n"
+
+		for _, object := range apiSpec.Objects {
+			markdown += "\n" + MarkdownObject(object, "###", objectLink)
+		}
+	}
+
+	return []byte(markdown)
+}
+
+// Renders an index.md along with one file per action and object ref.
+func GenerateMarkdownFiles(apiSpec ApiSpec, title string) map[string][]byte {
+	files := make(map[string][]byte)
+
+	objectLink := func(ref string) string {
+		return "../" + MarkdownObjectFile(ref) + "#" + MarkdownObjectAnchor(ref)
+	}
+
+	files["index.md"] = []byte(MarkdownIndex(apiSpec, title, MarkdownActionFile, MarkdownObjectFile))
+
+	for _, action := range apiSpec.Actions {
+		files[MarkdownActionFile(action.Ref)] = []byte(MarkdownAction(action, "#", objectLink))
+	}
+
+	for _, object := range apiSpec.Objects {
+		files[MarkdownObjectFile(object.Ref)] = []byte(MarkdownObject(object, "#", objectLink))
+	}
+
+	return files
+}
+
+func MarkdownIndex(apiSpec ApiSpec, title string, actionLink func(string) string, objectLink func(string) string) string {
+	markdown := "# " + title + "\n"
+
+	if len(apiSpec.Actions) > 0 {
+		markdown += "\n**Actions**\n\n"
+
+		for _, action := range apiSpec.Actions {
+			markdown += "- [" + action.Name + "](" + actionLink(action.Ref) + ")\n"
+		}
+	}
+
+	if len(apiSpec.Objects) > 0 {
+		markdown += "\n**Objects**\n\n"
+
+		for _, object := range apiSpec.Objects {
+			markdown += "- [" + object.Name + "](" + objectLink(object.Ref) + ")\n"
+		}
+	}
+
+	return markdown
+}
+
+func MarkdownAction(action Action, heading string, objectLink func(string) string) string {
+	markdown := "<a id=\"" + MarkdownActionAnchor(action.Ref) + "\"></a>\n" +
+		heading + " " + action.Name + "\n\n" +
+		"- **Ref:** `" + action.Ref + "`\n" +
+		"- **Uri:** `" + ActionMethod(action) + " " + action.Uri + "`\n"
+
+	if len(action.Description) > 0 {
+		markdown += "\n" + action.Description + "\n"
+	}
+
+	markdown += markdownNotes(action.Notes)
+
+	markdown += "\n" + heading + "# Parameters\n\n" + MarkdownKeyValues(action.Parameters, objectLink)
+	if len(action.Responses) == 0 {
+		markdown += "\n" + heading + "# Returns\n\n" + MarkdownKeyValues(action.Returns, objectLink)
+	}
+
+	for _, status := range ResponseStatuses(action) {
+		markdown += "\n" + heading + "# Returns " + status + "\n\n"
+
+		if len(action.Responses[status].Description) > 0 {
+			markdown += action.Responses[status].Description + "\n\n"
+		}
+
+		markdown += MarkdownKeyValues(action.Responses[status].Returns, objectLink)
+	}
+
+	return markdown
+}
+
+func MarkdownObject(object Object, heading string, objectLink func(string) string) string {
+	markdown := "<a id=\"" + MarkdownObjectAnchor(object.Ref) + "\"></a>\n" +
+		heading + " " + object.Name + "\n\n" +
+		"- **Ref:** `" + object.Ref + "`\n"
+
+	if len(object.Description) > 0 {
+		markdown += "\n" + object.Description + "\n"
+	}
+
+	markdown += markdownNotes(object.Notes)
+
+	markdown += "\n" + heading + "# Properties\n\n" + MarkdownKeyValues(object.Properties, objectLink)
+
+	return markdown
+}
+
+// Renders key/values as a table, with nested values listed by their full
+// dotted object.space.
+func MarkdownKeyValues(keyValues []KeyValue, objectLink func(string) string) string {
+	if len(keyValues) == 0 {
+		return "_None._\n"
+	}
+
+	return "| Name | Type | Flag | Description |\n" +
+		"| ---- | ---- | ---- | ----------- |\n" +
+		markdownKeyValueRows(keyValues, "", objectLink)
+}
+
+func markdownKeyValueRows(keyValues []KeyValue, objectspace string, objectLink func(string) string) string {
+	rows := ""
+
+	for _, keyValue := range keyValues {
+		keyValueType := keyValue.Type
+
+		if ref, ok := TypeRef(keyValue.Type); ok {
+			keyValueType = "[" + ref + "](" + objectLink(ref) + ")"
+		} else if keyValue.Limit > 0 {
+			keyValueType += ", " + strconv.FormatInt(keyValue.Limit, 10)
+		}
+
+		keyValueFlag := keyValue.Flag
+
+		if len(keyValue.Location) > 0 {
+			keyValueFlag = strings.TrimPrefix(keyValueFlag+", "+keyValue.Location, ", ")
+		}
+
+		rows += "| `" + objectspace + keyValue.Name + "` | " +
+			keyValueType + " | " +
+			keyValueFlag + " | " +
+			markdownEscapeCell(keyValue.Description) + " |\n"
+
+		rows += markdownKeyValueRows(keyValue.Children, objectspace+keyValue.Name+".", objectLink)
+	}
+
+	return rows
+}
+
+func markdownNotes(notes []string) string {
+	if len(notes) == 0 {
+		return ""
+	}
+
+	markdown := "\n"
+
+	for _, note := range notes {
+		markdown += "> " + note + "\n>\n"
+	}
+
+	return strings.TrimSuffix(markdown, ">\n")
+}
+
+func markdownEscapeCell(value string) string {
+	return strings.Replace(value, "|", "\\|", -1)
+}
@@ -0,0 +1,55 @@
+package atoz
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdownKeyValues(t *testing.T) {
+	keyValues := []KeyValue{
+		KeyValue{Name: "auth", Flag: "required", Type: "object", Limit: -1, Children: []KeyValue{
+			KeyValue{Name: "key", Type: "string", Limit: 64, Description: "Key | token.", Children: []KeyValue{}},
+		}},
+		KeyValue{Name: "user", Flag: "success", Type: "#/Application/User#", Limit: -1, Description: "The user.", Children: []KeyValue{}},
+	}
+
+	expected := "| Name | Type | Flag | Description |\n" +
+		"| ---- | ---- | ---- | ----------- |\n" +
+		"| `auth` | object | required |  |\n" +
+		"| `auth.key` | string, 64 |  | Key \\| token. |\n" +
+		"| `user` | [/Application/User](#object-Application.User) | success | The user. |\n"
+
+	result := MarkdownKeyValues(keyValues, func(ref string) string {
+		return "#" + MarkdownObjectAnchor(ref)
+	})
+
+	if result != expected {
+		t.Errorf("TestMarkdownKeyValues Mismatch\nExpected:\n%s\n  Actual:\n%s", expected, result)
+	}
+}
+
+func TestGenerateMarkdownFiles(t *testing.T) {
+	apiSpec := ApiSpec{
+		Actions: []Action{
+			Action{Name: "User Lookup", Ref: "/MyApp/User/Lookup", Method: "POST", Uri: "/User/Lookup", Notes: []string{}, Parameters: []KeyValue{}, Returns: []KeyValue{
+				KeyValue{Name: "user", Type: "#/Application/User#", Limit: -1, Children: []KeyValue{}},
+			}},
+		},
+		Objects: []Object{
+			Object{Name: "User", Ref: "/Application/User", Notes: []string{}, Properties: []KeyValue{}},
+		},
+	}
+
+	files := GenerateMarkdownFiles(apiSpec, "Test")
+
+	for _, name := range []string{"index.md", "actions/MyApp.User.Lookup.md", "objects/Application.User.md"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("TestGenerateMarkdownFiles Missing file: %s", name)
+			return
+		}
+	}
+
+	if !strings.Contains(string(files["actions/MyApp.User.Lookup.md"]), "(../objects/Application.User.md#object-Application.User)") {
+		t.Errorf("TestGenerateMarkdownFiles Missing object link:\n%s", files["actions/MyApp.User.Lookup.md"])
+	}
+}
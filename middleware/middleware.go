@@ -0,0 +1,100 @@
+// Package middleware checks the requests sent to a net/http service against
+// the actions documented with Atoz.
+//
+// The spec can be built with an atoz.Parser, or read from the JSON written by
+// atoz:
+//
+//	atoz -dir path/to/source/tree -output api.json
+//
+// which is loaded and wrapped around the service's handler:
+//
+//	spec, err := middleware.Load("api.json")
+//	http.ListenAndServe(":8080", middleware.Validate(spec, handler))
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/funnylookinhat/atoz"
+)
+
+// The body of the 400 response sent for an invalid request.
+type ErrorResponse struct {
+	Error  string                 `json:"error"`
+	Ref    string                 `json:"ref"`
+	Errors []atoz.ValidationError `json:"errors"`
+}
+
+// Read the spec from a JSON file written by atoz.
+func Load(path string) (atoz.ApiSpec, error) {
+	var apiSpec atoz.ApiSpec
+
+	contents, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return apiSpec, err
+	}
+
+	err = json.Unmarshal(contents, &apiSpec)
+
+	return apiSpec, err
+}
+
+// Return a handler that checks the path, query, headers and JSON body of every
+// request routed to an action of the spec before passing it on to next.
+// Requests that don't match an action are passed on as they are.
+func Validate(apiSpec atoz.ApiSpec, next http.Handler) http.Handler {
+	objects := atoz.ObjectsByRef(apiSpec.Objects)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		action := atoz.MatchAction(apiSpec.Actions, r.Method, r.URL.Path)
+
+		if action == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var body []byte
+		var err error
+
+		if r.Body != nil {
+			body, err = ioutil.ReadAll(r.Body)
+			r.Body.Close()
+		}
+
+		if err != nil {
+			writeErrors(w, *action, []atoz.ValidationError{atoz.ValidationError{Message: err.Error()}})
+			return
+		}
+
+		errors := atoz.ValidateRequestParameters(action.Parameters, atoz.UriValues(action.Uri, r.URL.Path), r.URL.Query(), r.Header)
+		errors = append(errors, atoz.ValidateRequestBody(atoz.LocationParameters(action.Parameters, ""), objects, body)...)
+
+		if len(errors) > 0 {
+			writeErrors(w, *action, errors)
+			return
+		}
+
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Return the Validate middleware in the shape most routers accept.
+func Middleware(apiSpec atoz.ApiSpec) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return Validate(apiSpec, next)
+	}
+}
+
+func writeErrors(w http.ResponseWriter, action atoz.Action, errors []atoz.ValidationError) {
+	body, _ := json.Marshal(ErrorResponse{"Invalid request", action.Ref, errors})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	w.Write(body)
+}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/funnylookinhat/atoz"
+)
+
+const testSpecJson = `{
+	"actions": [
+		{
+			"ref": "/MyApp/User/Update",
+			"method": "POST",
+			"uri": "/users/{id}",
+			"parameters": [
+				{"name": "id", "flag": "required", "type": "integer", "limit": -1, "children": [], "location": "path"},
+				{"name": "x-auth-token", "flag": "required", "type": "string", "limit": 0, "children": [], "location": "header"},
+				{"name": "auth", "flag": "required", "type": "object", "limit": -1, "children": [
+					{"name": "token", "flag": "required", "type": "string", "limit": 8, "children": []}
+				]},
+				{"name": "user", "flag": "", "type": "#/Application/User#", "limit": -1, "children": []},
+				{"name": "scores", "flag": "", "type": "array", "limit": 2, "children": []}
+			]
+		}
+	],
+	"objects": [
+		{
+			"ref": "/Application/User",
+			"properties": [
+				{"name": "email", "flag": "required", "type": "string", "limit": 0, "children": []},
+				{"name": "balance", "flag": "", "type": "decimal", "limit": 2, "children": []}
+			]
+		}
+	]
+}`
+
+type testValidateCase struct {
+	method string
+	path   string
+	token  string
+	body   string
+	status int
+	result string
+}
+
+var testValidateCases = []testValidateCase{
+	{"POST", "/users/12", "abc", `{"auth": {"token": "abc"}, "user": {"email": "a@b.c", "balance": 1.5}, "scores": [1, 2]}`, 200, `{"auth": {"token": "abc"}, "user": {"email": "a@b.c", "balance": 1.5}, "scores": [1, 2]}`},
+	{"POST", "/users/12", "abc", ``, 400, `{"error":"Invalid request","ref":"/MyApp/User/Update","errors":[{"path":"auth","message":"is required"}]}`},
+	{"POST", "/users/12", "abc", `{"auth": {"token": "abcdefghi"}, "user": {"balance": 1.555}, "scores": [1, 2, 3]}`, 400, `{"error":"Invalid request","ref":"/MyApp/User/Update","errors":[{"path":"auth.token","message":"expected at most 8 characters"},{"path":"user.email","message":"is required"},{"path":"user.balance","message":"expected at most 2 decimal places"},{"path":"scores","message":"expected at most 2 elements"}]}`},
+	{"POST", "/users/12", "abc", `{"auth": 5}`, 400, `{"error":"Invalid request","ref":"/MyApp/User/Update","errors":[{"path":"auth","message":"expected an object"}]}`},
+	{"GET", "/users/12", "", `not json`, 200, `not json`},
+	{"POST", "/health", "", `not json`, 200, `not json`},
+	{"POST", "/users/12", "", `{"auth": {"token": "abc"}}`, 400, `{"error":"Invalid request","ref":"/MyApp/User/Update","errors":[{"path":"x-auth-token","message":"is required"}]}`},
+	{"POST", "/users/abc", "abc", `{"auth": {"token": "abc"}}`, 400, `{"error":"Invalid request","ref":"/MyApp/User/Update","errors":[{"path":"id","message":"expected an integer"}]}`},
+}
+
+func TestValidate(t *testing.T) {
+	var apiSpec atoz.ApiSpec
+
+	if err := json.Unmarshal([]byte(testSpecJson), &apiSpec); err != nil {
+		t.Errorf("TestValidate Unexpected error: %s", err)
+		return
+	}
+
+	// Echo the body back to show it's passed on untouched.
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write(body)
+	})
+
+	handler := Middleware(apiSpec)(echo)
+
+	for _, test := range testValidateCases {
+		request, err := http.NewRequest(test.method, test.path, bytes.NewBufferString(test.body))
+
+		if err != nil {
+			t.Errorf("TestValidate Unexpected error: %s", err)
+			continue
+		}
+
+		if len(test.token) > 0 {
+			request.Header.Set("X-Auth-Token", test.token)
+		}
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, request)
+
+		if recorder.Code != test.status || recorder.Body.String() != test.result {
+			t.Errorf("TestValidate Mismatch: %s %s\nExpected: %d %s\n  Actual: %d %s", test.method, test.path, test.status, test.result, recorder.Code, recorder.Body.String())
+		}
+	}
+}
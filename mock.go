@@ -0,0 +1,287 @@
+package atoz
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The request header that picks the response of the mock server - either
+// "success", "failure" or a status code of the action.
+const MockResponseHeader = "X-Atoz-Response"
+
+// Serves a synthesized response for every action of the source tree in dir,
+// and rebuilds the spec when the files change.
+type MockServer struct {
+	dir    string
+	parser Parser
+	mutex  sync.RWMutex
+
+	apiSpec ApiSpec
+	objects map[string]Object
+
+	// The files the last build was attempted from, so a tree with errors is
+	// only reported once.
+	stamp uint64
+	built bool
+}
+
+// Nothing is served until the first Reload.
+func NewMockServer(dir string, parser Parser) *MockServer {
+	return &MockServer{dir: dir, parser: parser}
+}
+
+// Rebuild the spec if any file in the tree was added, removed or modified
+// since the last build.  A tree with errors leaves the last spec in place.
+// Return whether the spec was rebuilt, along with any warnings or errors.
+func (m *MockServer) Reload() (bool, error) {
+	files, err := FindFiles(m.dir)
+
+	if err != nil {
+		return false, err
+	}
+
+	stamp, err := filesStamp(files)
+
+	if err != nil {
+		return false, err
+	}
+
+	m.mutex.RLock()
+	unchanged := m.built && stamp == m.stamp
+	m.mutex.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	apiSpec, err := m.parser.Parse(files)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.stamp = stamp
+	m.built = true
+
+	if diagnostics, ok := err.(Diagnostics); (ok && diagnostics.HasErrors()) || (err != nil && !ok) {
+		return false, err
+	}
+
+	m.apiSpec = apiSpec
+	m.objects = ObjectsByRef(apiSpec.Objects)
+
+	return true, err
+}
+
+// Check the tree for changes every interval, logging each reload.
+func (m *MockServer) Watch(interval time.Duration) {
+	for range time.Tick(interval) {
+		reloaded, err := m.Reload()
+
+		if err != nil {
+			log.Printf("%s", err)
+		}
+
+		if reloaded {
+			log.Printf("Reloaded %s", m.dir)
+		}
+	}
+}
+
+func (m *MockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mutex.RLock()
+	actions := m.apiSpec.Actions
+	objects := m.objects
+	m.mutex.RUnlock()
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	if r.Method == "OPTIONS" {
+		w.Header().Set("Access-Control-Allow-Methods", strings.Join(MatchUriMethods(actions, r.URL.Path), ", "))
+		w.Header().Set("Access-Control-Allow-Headers", "*")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	action := MatchAction(actions, r.Method, r.URL.Path)
+
+	if action == nil {
+		if methods := MatchUriMethods(actions, r.URL.Path); len(methods) > 0 {
+			w.Header().Set("Allow", strings.Join(methods, ", "))
+			writeJson(w, http.StatusMethodNotAllowed, map[string]interface{}{"error": "Method not allowed"})
+			return
+		}
+
+		writeJson(w, http.StatusNotFound, map[string]interface{}{"error": "No action found for " + r.URL.Path})
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+
+	if err != nil {
+		writeJson(w, http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	if errors := ValidateRequestBody(LocationParameters(action.Parameters, ""), objects, body); len(errors) > 0 {
+		writeJson(w, http.StatusBadRequest, map[string]interface{}{"error": "Invalid request", "errors": errors})
+		return
+	}
+
+	status, response, err := MockResponse(*action, objects, r.Header.Get(MockResponseHeader))
+
+	if err != nil {
+		writeJson(w, http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	writeJson(w, status, response)
+}
+
+// Receive an action and the variant asked for - "success", "failure" or one of
+// the status codes of the action - blank for success
+// Return the status code and body to respond with.
+func MockResponse(action Action, objects map[string]Object, variant string) (int, map[string]interface{}, error) {
+	if len(variant) == 0 {
+		variant = "success"
+	}
+
+	if variant != "success" && variant != "failure" {
+		response, ok := action.Responses[variant]
+
+		if !ok {
+			return 0, nil, fmt.Errorf("%s has no %s response", action.Ref, variant)
+		}
+
+		status, _ := strconv.Atoi(variant)
+
+		return status, ExampleKeyValues(response.Returns, objects), nil
+	}
+
+	// The lowest status code in the range of the variant, if the action has
+	// one.
+	for _, status := range ResponseStatuses(action) {
+		code, _ := strconv.Atoi(status)
+
+		if (variant == "success") == (code < 400) {
+			return code, ExampleKeyValues(action.Responses[status].Returns, objects), nil
+		}
+	}
+
+	if variant == "failure" {
+		return http.StatusBadRequest, ExampleResponse(action, objects, "failure"), nil
+	}
+
+	return http.StatusOK, ExampleResponse(action, objects, "success"), nil
+}
+
+// Receive the actions of a spec along with the method and path of a request
+// Return the action it's routed to, or nil.  A {placeholder} in a uri matches
+// any single segment of the path.
+func MatchAction(actions []Action, method string, path string) *Action {
+	for i, action := range actions {
+		if strings.EqualFold(ActionMethod(action), method) && MatchUri(action.Uri, path) {
+			return &actions[i]
+		}
+	}
+
+	return nil
+}
+
+// Return the methods of every action whose uri matches the path.
+func MatchUriMethods(actions []Action, path string) []string {
+	methods := make([]string, 0)
+
+	for _, action := range actions {
+		if MatchUri(action.Uri, path) {
+			methods = append(methods, strings.ToUpper(ActionMethod(action)))
+		}
+	}
+
+	sort.Strings(methods)
+
+	return methods
+}
+
+// Receive
+// /api/user/{id} and /api/user/12
+// Return true
+func MatchUri(uri string, path string) bool {
+	return UriValues(uri, path) != nil
+}
+
+// Receive
+// /api/user/{userId} and /api/user/12
+// Return map[userid:12], or nil if the path doesn't match the uri.  Names are
+// lowercased, since placeholders match path parameters regardless of case.
+func UriValues(uri string, path string) map[string]string {
+	if len(uri) == 0 {
+		return nil
+	}
+
+	uriParts := strings.Split(strings.Trim(uri, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+
+	if len(uriParts) != len(pathParts) {
+		return nil
+	}
+
+	values := make(map[string]string)
+
+	for i, uriPart := range uriParts {
+		if len(uriPart) > 0 && uriPlaceholder.FindString(uriPart) == uriPart {
+			if len(pathParts[i]) == 0 {
+				return nil
+			}
+
+			values[strings.ToLower(uriPart[1:len(uriPart)-1])] = pathParts[i]
+
+			continue
+		}
+
+		if uriPart != pathParts[i] {
+			return nil
+		}
+	}
+
+	return values
+}
+
+func writeJson(w http.ResponseWriter, status int, value interface{}) {
+	body, err := json.Marshal(value)
+
+	if err != nil {
+		status = http.StatusInternalServerError
+		body = []byte(`{"error":"` + err.Error() + `"}`)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// A hash of the name, size and modification time of every file.
+func filesStamp(files []string) (uint64, error) {
+	hash := fnv.New64a()
+
+	for _, file := range files {
+		info, err := os.Stat(file)
+
+		if err != nil {
+			return 0, err
+		}
+
+		fmt.Fprintf(hash, "%s %d %d\n", file, info.Size(), info.ModTime().UnixNano())
+	}
+
+	return hash.Sum64(), nil
+}
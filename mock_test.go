@@ -0,0 +1,196 @@
+package atoz
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testMockSource = `/**
+ * ---ATOZAPI---
+ * @name Lookup
+ * @ref /MyApp/User/Lookup
+ * @method GET
+ * @uri /users/{id}
+ * @path {Integer} id
+ * @success {Boolean} success
+ * @failure {String} error
+ * @failure:409 {String} conflict
+ * ---ATOZEND---
+ * ---ATOZAPI---
+ * @name Create
+ * @ref /MyApp/User/Create
+ * @method POST
+ * @uri /users
+ * @required {String} name
+ * @success:201 {Integer} id
+ * ---ATOZEND---
+ */
+`
+
+type testMockServerCase struct {
+	method string
+	path   string
+	header string
+	body   string
+	status int
+	result string
+}
+
+var testMockServerCases = []testMockServerCase{
+	{"GET", "/users/12", "", "", 200, `{"success":true}`},
+	{"GET", "/users/12", "failure", "", 409, `{"conflict":"conflict","error":"error"}`},
+	{"GET", "/users/12", "409", "", 409, `{"conflict":"conflict","error":"error"}`},
+	{"GET", "/users/12", "404", "", 400, `{"error":"/MyApp/User/Lookup has no 404 response"}`},
+	{"POST", "/users", "", `{"name":"Jo"}`, 201, `{"id":1}`},
+	{"POST", "/users", "", `{"name":12}`, 400, `{"error":"Invalid request","errors":[{"path":"name","message":"expected a string"}]}`},
+	{"POST", "/users", "", `{`, 400, `{"error":"Invalid request","errors":[{"path":"","message":"Invalid JSON: unexpected EOF"}]}`},
+	{"DELETE", "/users", "", "", 405, `{"error":"Method not allowed"}`},
+	{"GET", "/users/12/friends", "", "", 404, `{"error":"No action found for /users/12/friends"}`},
+}
+
+func TestMockServer(t *testing.T) {
+	dir := writeSpecFiles(t, map[string]string{"user.js": testMockSource})
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "user.js")
+	mockServer := NewMockServer(dir, Parser{})
+
+	if _, err := mockServer.Reload(); err != nil {
+		t.Errorf("TestMockServer Unexpected error: %s", err)
+		return
+	}
+
+	server := httptest.NewServer(mockServer)
+	defer server.Close()
+
+	for _, test := range testMockServerCases {
+		request, _ := http.NewRequest(test.method, server.URL+test.path, bytes.NewBufferString(test.body))
+
+		if len(test.header) > 0 {
+			request.Header.Set(MockResponseHeader, test.header)
+		}
+
+		response, err := http.DefaultClient.Do(request)
+
+		if err != nil {
+			t.Errorf("TestMockServer Unexpected error: %s", err)
+			continue
+		}
+
+		body, _ := ioutil.ReadAll(response.Body)
+		response.Body.Close()
+
+		if response.StatusCode != test.status || string(body) != test.result {
+			t.Errorf("TestMockServer Mismatch: %s %s\nExpected: %d %s\n  Actual: %d %s", test.method, test.path, test.status, test.result, response.StatusCode, body)
+		}
+	}
+
+	// An unchanged tree isn't rebuilt, a tree with errors keeps the last
+	// spec, and a fixed tree replaces it.
+	if reloaded, _ := mockServer.Reload(); reloaded {
+		t.Errorf("TestMockServer Reloaded an unchanged tree")
+	}
+
+	later := time.Now().Add(time.Minute)
+
+	if err := ioutil.WriteFile(file, []byte(" * ---ATOZAPI---\n"), 0644); err != nil {
+		t.Errorf("TestMockServer Unexpected error: %s", err)
+		return
+	}
+
+	os.Chtimes(file, later, later)
+
+	if reloaded, err := mockServer.Reload(); reloaded || err == nil {
+		t.Errorf("TestMockServer Expected an error reloading a broken tree")
+	}
+
+	if MatchAction(mockServer.apiSpec.Actions, "POST", "/users") == nil {
+		t.Errorf("TestMockServer Lost the last spec reloading a broken tree")
+	}
+
+	source := bytes.Replace([]byte(testMockSource), []byte("@uri /users\n"), []byte("@uri /people\n"), 1)
+
+	if err := ioutil.WriteFile(file, source, 0644); err != nil {
+		t.Errorf("TestMockServer Unexpected error: %s", err)
+		return
+	}
+
+	later = later.Add(time.Minute)
+	os.Chtimes(file, later, later)
+
+	if reloaded, err := mockServer.Reload(); !reloaded || err != nil {
+		t.Errorf("TestMockServer Expected a reload: %v", err)
+	}
+
+	if MatchAction(mockServer.apiSpec.Actions, "POST", "/people") == nil {
+		t.Errorf("TestMockServer Didn't serve the reloaded spec")
+	}
+}
+
+type testMatchUriCase struct {
+	uri    string
+	path   string
+	result bool
+}
+
+var testMatchUriCases = []testMatchUriCase{
+	{"/api/user/{id}", "/api/user/12", true},
+	{"/api/user/{id}", "/api/user/12/", true},
+	{"/api/user/{id}", "/api/user/", false},
+	{"/api/user/{id}/friends", "/api/user/12/friends", true},
+	{"/api/user", "/api/users", false},
+	{"", "/", false},
+}
+
+func TestMatchUri(t *testing.T) {
+	for _, test := range testMatchUriCases {
+		if result := MatchUri(test.uri, test.path); result != test.result {
+			t.Errorf("TestMatchUri Mismatch: %s %s\nExpected: %v\n  Actual: %v", test.uri, test.path, test.result, result)
+		}
+	}
+}
+
+type testUriValuesCase struct {
+	uri    string
+	path   string
+	result map[string]string
+}
+
+var testUriValuesCases = []testUriValuesCase{
+	{"/api/user/{userId}/friends/{id}", "/api/user/12/friends/abc", map[string]string{"userid": "12", "id": "abc"}},
+	{"/api/user", "/api/user", map[string]string{}},
+	{"/api/user/{id}", "/api/user/", nil},
+}
+
+func TestUriValues(t *testing.T) {
+	for _, test := range testUriValuesCases {
+		if result := UriValues(test.uri, test.path); !reflect.DeepEqual(result, test.result) {
+			t.Errorf("TestUriValues Mismatch: %s %s\nExpected: %v\n  Actual: %v", test.uri, test.path, test.result, result)
+		}
+	}
+}
+
+func TestMockResponse(t *testing.T) {
+	action := Action{Ref: "/MyApp/User/Lookup", Returns: []KeyValue{
+		KeyValue{Name: "success", Flag: "success", Type: "boolean", Limit: -1, Children: []KeyValue{}},
+		KeyValue{Name: "error", Flag: "failure", Type: "string", Children: []KeyValue{}},
+	}}
+
+	for variant, expected := range map[string]string{"": `200 {"success":true}`, "failure": `400 {"error":"error"}`} {
+		status, response, err := MockResponse(action, map[string]Object{}, variant)
+		body, _ := json.Marshal(response)
+
+		if result := fmt.Sprintf("%d %s", status, body); result != expected || err != nil {
+			t.Errorf("TestMockResponse Mismatch: %s\nExpected: %s\n  Actual: %s %v", variant, expected, result, err)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package atoz
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type OpenApiDocument struct {
+	OpenApi    string                     `json:"openapi"`
+	Info       ApiInfo                    `json:"info"`
+	Paths      map[string]OpenApiPathItem `json:"paths"`
+	Components OpenApiComponents          `json:"components"`
+}
+
+type ApiInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Keyed by lowercase HTTP method.
+type OpenApiPathItem map[string]OpenApiOperation
+
+type OpenApiOperation struct {
+	OperationId string                     `json:"operationId"`
+	Summary     string                     `json:"summary,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	Parameters  []OpenApiParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenApiRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]OpenApiResponse `json:"responses"`
+	Deprecated  bool                       `json:"deprecated,omitempty"`
+}
+
+type OpenApiParameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+type OpenApiRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]OpenApiMediaType `json:"content"`
+}
+
+type OpenApiResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]OpenApiMediaType `json:"content,omitempty"`
+}
+
+type OpenApiMediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type OpenApiComponents struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+const openApiVersion = "3.1.0"
+
+func openApiRefPath(ref string) string {
+	return "#/components/schemas/" + SchemaName(ref)
+}
+
+func GenerateOpenApi(apiSpec ApiSpec, info ApiInfo) OpenApiDocument {
+	document := OpenApiDocument{
+		OpenApi: openApiVersion,
+		Info:    info,
+		Paths:   make(map[string]OpenApiPathItem),
+		Components: OpenApiComponents{
+			Schemas: make(map[string]*Schema),
+		},
+	}
+
+	for _, action := range apiSpec.Actions {
+		path := OpenApiPath(action)
+
+		if _, ok := document.Paths[path]; !ok {
+			document.Paths[path] = make(OpenApiPathItem)
+		}
+
+		document.Paths[path][strings.ToLower(ActionMethod(action))] = GenerateOpenApiOperation(action)
+	}
+
+	for _, object := range apiSpec.Objects {
+		schema := KeyValuesSchema(object.Properties, openApiRefPath)
+		schema.Description = ObjectDescription(object)
+
+		document.Components.Schemas[SchemaName(object.Ref)] = schema
+	}
+
+	return document
+}
+
+// Placeholders in the uri match path parameters regardless of case, so each
+// one is named as its parameter is declared.
+func OpenApiPath(action Action) string {
+	return uriPlaceholder.ReplaceAllStringFunc(action.Uri, func(placeholder string) string {
+		if parameter := FindKeyValue(LocationParameters(action.Parameters, "path"), placeholder[1:len(placeholder)-1]); parameter != nil {
+			return "{" + parameter.Name + "}"
+		}
+
+		return placeholder
+	})
+}
+
+func GenerateOpenApiOperation(action Action) OpenApiOperation {
+	operation := OpenApiOperation{
+		OperationId: action.Ref,
+		Summary:     action.Name,
+		Description: ActionDescription(action),
+		Responses:   make(map[string]OpenApiResponse),
+		Deprecated:  action.Deprecated,
+	}
+
+	for _, location := range []string{"path", "query", "header"} {
+		for _, parameter := range LocationParameters(action.Parameters, location) {
+			schema := KeyValueSchema(parameter, openApiRefPath)
+			schema.Description = ""
+
+			operation.Parameters = append(operation.Parameters, OpenApiParameter{
+				parameter.Name,
+				location,
+				parameter.Description,
+				parameter.Flag == "required",
+				schema,
+			})
+		}
+	}
+
+	bodyParameters := LocationParameters(action.Parameters, "")
+
+	if len(bodyParameters) > 0 {
+		operation.RequestBody = &OpenApiRequestBody{
+			Required: HasFlag(bodyParameters, "required"),
+			Content: map[string]OpenApiMediaType{
+				"application/json": OpenApiMediaType{
+					KeyValuesSchema(bodyParameters, openApiRefPath),
+				},
+			},
+		}
+	}
+
+	if len(action.Responses) > 0 {
+		for status, response := range action.Responses {
+			operation.Responses[status] = OpenApiResponse{
+				ResponseDescription(status, response),
+				map[string]OpenApiMediaType{
+					"application/json": OpenApiMediaType{
+						KeyValuesSchema(response.Returns, openApiRefPath),
+					},
+				},
+			}
+		}
+
+		return operation
+	}
+
+	operation.Responses["200"] = OpenApiResponse{
+		"Success",
+		map[string]OpenApiMediaType{
+			"application/json": OpenApiMediaType{
+				KeyValuesSchema(FilterReturns(action.Returns, "success"), openApiRefPath),
+			},
+		},
+	}
+
+	if HasFlag(action.Returns, "failure") {
+		operation.Responses["default"] = OpenApiResponse{
+			"Failure",
+			map[string]OpenApiMediaType{
+				"application/json": OpenApiMediaType{
+					KeyValuesSchema(FilterReturns(action.Returns, "failure"), openApiRefPath),
+				},
+			},
+		}
+	}
+
+	return operation
+}
+
+// Joins the description and notes of an action into a single block of text.
+func ActionDescription(action Action) string {
+	return strings.TrimSpace(strings.Join(append([]string{action.Description}, action.Notes...), "\n\n"))
+}
+
+func ObjectDescription(object Object) string {
+	return strings.TrimSpace(strings.Join(append([]string{object.Description}, object.Notes...), "\n\n"))
+}
+
+// Fall back to the standard reason phrase when a status has no description.
+func ResponseDescription(status string, response Response) string {
+	if len(response.Description) > 0 {
+		return response.Description
+	}
+
+	code, _ := strconv.Atoi(status)
+
+	if text := http.StatusText(code); len(text) > 0 {
+		return text
+	}
+
+	return status
+}
+
+// Whether any top-level key/value carries the given flag.
+func HasFlag(keyValues []KeyValue, flag string) bool {
+	for _, keyValue := range keyValues {
+		if keyValue.Flag == flag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Receive the returns of an action and either "success" or "failure".
+// Return the top-level values returned in that case - unflagged values are
+// returned in both.
+func FilterReturns(returns []KeyValue, flag string) []KeyValue {
+	keyValues := make([]KeyValue, 0)
+
+	for _, keyValue := range returns {
+		if keyValue.Flag == "" || keyValue.Flag == flag {
+			keyValues = append(keyValues, keyValue)
+		}
+	}
+
+	return keyValues
+}
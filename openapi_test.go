@@ -0,0 +1,110 @@
+package atoz
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGenerateOpenApi(t *testing.T) {
+	apiSpec := ApiSpec{
+		Actions: []Action{
+			Action{
+				Name:        "User Lookup",
+				Ref:         "/MyApp/User/Lookup",
+				Method:      "POST",
+				Uri:         "/User/Lookup",
+				Description: "Get the information for a user.",
+				Notes:       []string{"Requires authorization."},
+				Parameters: []KeyValue{
+					KeyValue{Name: "id", Flag: "required", Type: "integer", Limit: -1, Children: []KeyValue{}},
+				},
+				Returns: []KeyValue{
+					KeyValue{Name: "error", Flag: "failure", Type: "string", Children: []KeyValue{}},
+					KeyValue{Name: "user", Flag: "success", Type: "#/Application/User#", Limit: -1, Children: []KeyValue{}},
+				},
+			},
+		},
+		Objects: []Object{
+			Object{
+				Name:        "User",
+				Ref:         "/Application/User",
+				Description: "A user.",
+				Notes:       []string{},
+				Properties: []KeyValue{
+					KeyValue{Name: "id", Type: "integer", Limit: -1, Children: []KeyValue{}},
+				},
+			},
+		},
+	}
+
+	document := GenerateOpenApi(apiSpec, ApiInfo{Title: "Test", Version: "1.0.0"})
+
+	operation, ok := document.Paths["/User/Lookup"]["post"]
+
+	if !ok {
+		t.Errorf("TestGenerateOpenApi Missing operation for /User/Lookup")
+		return
+	}
+
+	if operation.Description != "Get the information for a user.\n\nRequires authorization." {
+		t.Errorf("TestGenerateOpenApi Description Mismatch: %s", operation.Description)
+	}
+
+	if operation.RequestBody == nil || !operation.RequestBody.Required {
+		t.Errorf("TestGenerateOpenApi Request body should be required.")
+	}
+
+	expectedSuccess := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"user": &Schema{Ref: "#/components/schemas/Application.User"},
+		},
+	}
+
+	if !reflect.DeepEqual(operation.Responses["200"].Content["application/json"].Schema, expectedSuccess) {
+		t.Errorf("TestGenerateOpenApi Success Response Mismatch: %+v", operation.Responses["200"])
+	}
+
+	if _, ok := operation.Responses["default"]; !ok {
+		t.Errorf("TestGenerateOpenApi Missing failure response.")
+	}
+
+	if _, ok := document.Components.Schemas["Application.User"]; !ok {
+		t.Errorf("TestGenerateOpenApi Missing schema for /Application/User")
+	}
+}
+
+func TestGenerateOpenApiParameters(t *testing.T) {
+	apiSpec := ApiSpec{
+		Actions: []Action{
+			Action{
+				Ref:    "/User/Get",
+				Method: "GET",
+				Uri:    "/api/user/{userId}",
+				Parameters: []KeyValue{
+					KeyValue{Name: "userId", Flag: "required", Type: "integer", Limit: -1, Location: "path"},
+					KeyValue{Name: "pageSize", Type: "integer", Limit: -1, Location: "query"},
+					KeyValue{Name: "verbose", Type: "boolean", Limit: -1},
+				},
+			},
+		},
+		Objects: []Object{},
+	}
+
+	document := GenerateOpenApi(apiSpec, ApiInfo{Title: "Test", Version: "1.0.0"})
+
+	operation, ok := document.Paths["/api/user/{userId}"]["get"]
+
+	if !ok {
+		t.Errorf("TestGenerateOpenApiParameters Missing operation: %+v", document.Paths)
+		return
+	}
+
+	if len(operation.Parameters) != 2 || operation.Parameters[0].In != "path" || !operation.Parameters[0].Required || operation.Parameters[1].Name != "pageSize" {
+		t.Errorf("TestGenerateOpenApiParameters Parameters Mismatch: %+v", operation.Parameters)
+	}
+
+	if _, ok := operation.RequestBody.Content["application/json"].Schema.Properties["userId"]; ok {
+		t.Errorf("TestGenerateOpenApiParameters Path parameter should not be in the body.")
+	}
+}
@@ -0,0 +1,97 @@
+package atoz
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var pathSeparator string = runeToAscii(os.PathSeparator)
+
+// Turns source trees into an ApiSpec.  The zero value parses strictly and
+// leaves out examples and sources.
+type Parser struct {
+	// Warn about {#/Ref#} types that match no object instead of failing.
+	Lax bool
+
+	// Set the exampleRequest and exampleResponse of every action.
+	Examples bool
+
+	// Keep the source of every action, object and key/value.
+	Sources bool
+}
+
+// Receive a list of files
+// Return the spec of the groups in them, along with the Diagnostics of any
+// problems found.  The spec leaves out anything with an error, so it can
+// still be used when there are errors.
+func (p Parser) Parse(files []string) (ApiSpec, error) {
+	apiSpec, err := GenerateApiSpec(files, p.Lax)
+
+	if p.Examples {
+		AddExamples(&apiSpec)
+	}
+
+	if !p.Sources {
+		RemoveSources(&apiSpec)
+	}
+
+	return apiSpec, err
+}
+
+// Parse every file under dir that isn't hidden.
+func (p Parser) ParseDir(dir string) (ApiSpec, error) {
+	files, err := FindFiles(dir)
+
+	if err != nil {
+		return ApiSpec{}, err
+	}
+
+	return p.Parse(files)
+}
+
+// Return every file under dir, skipping hidden files and directories.
+func FindFiles(dir string) ([]string, error) {
+	var err error
+	files := make([]string, 0)
+
+	err = filepath.Walk(dir, func(path string, file os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !isHidden(path) && !file.IsDir() {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+
+	return files, err
+}
+
+func isHidden(path string) bool {
+	for i, part := range strings.Split(path, string(pathSeparator)) {
+		if len(part) > 0 {
+			if part[0:1] == "." && i == 0 {
+				// Nada
+			} else if len(part) > 1 && part[0:2] == ".." {
+				// Nada
+			} else if part[0:1] == "." && len(part) > 1 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Pretty dang useful - http://stackoverflow.com/a/16684343
+func runeToAscii(r rune) string {
+	if r < 128 {
+		return string(r)
+	} else {
+		return "\\u" + strconv.FormatInt(int64(r), 16)
+	}
+}
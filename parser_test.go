@@ -0,0 +1,79 @@
+package atoz
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testParserSource = `/**
+ * ---ATOZAPI---
+ * @name Lookup
+ * @ref /MyApp/User/Lookup
+ * @required {Integer} id
+ * @success {#/Application/Missing#} user
+ * ---ATOZEND---
+ */
+`
+
+func TestParserParseDir(t *testing.T) {
+	dir := writeSpecFiles(t, map[string]string{
+		"user.js":                           testParserSource,
+		filepath.Join(".hidden", "user.js"): testParserSource,
+	})
+	defer os.RemoveAll(dir)
+
+	_, err := Parser{}.ParseDir(dir)
+
+	if diagnostics, ok := err.(Diagnostics); !ok || !diagnostics.HasErrors() {
+		t.Errorf("TestParserParseDir Expected an unknown ref error: %v", err)
+	}
+
+	apiSpec, err := Parser{Lax: true, Examples: true}.ParseDir(dir)
+
+	if diagnostics, ok := err.(Diagnostics); !ok || diagnostics.HasErrors() || len(diagnostics) != 1 {
+		t.Errorf("TestParserParseDir Expected a single unknown ref warning: %v", err)
+	}
+
+	if len(apiSpec.Actions) != 1 {
+		t.Errorf("TestParserParseDir Expected one action, hidden files should be skipped: %v", apiSpec.Actions)
+		return
+	}
+
+	expected := map[string]interface{}{"id": 1}
+
+	if !reflect.DeepEqual(apiSpec.Actions[0].ExampleRequest, expected) {
+		t.Errorf("TestParserParseDir Mismatch:\nExpected: %v\n  Actual: %v", expected, apiSpec.Actions[0].ExampleRequest)
+	}
+
+	if apiSpec.Actions[0].Source != nil || apiSpec.Actions[0].Parameters[0].Source != nil {
+		t.Errorf("TestParserParseDir Sources should be left out: %v", apiSpec.Actions[0])
+	}
+
+	apiSpec, _ = Parser{Lax: true, Sources: true}.ParseDir(dir)
+
+	if len(apiSpec.Actions) != 1 || apiSpec.Actions[0].Source == nil || apiSpec.Actions[0].Parameters[0].Source == nil {
+		t.Errorf("TestParserParseDir Sources should be kept: %v", apiSpec.Actions)
+	}
+}
+
+type testIsHiddenCase struct {
+	path   string
+	result bool
+}
+
+var testIsHiddenCases = []testIsHiddenCase{
+	{"./src/user.js", false},
+	{"../src/user.js", false},
+	{"src/.git/config", true},
+	{"src/.eslintrc", true},
+}
+
+func TestIsHidden(t *testing.T) {
+	for _, test := range testIsHiddenCases {
+		if result := isHidden(filepath.FromSlash(test.path)); result != test.result {
+			t.Errorf("TestIsHidden Mismatch: %s\nExpected: %v\n  Actual: %v", test.path, test.result, result)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package atoz
+
+import (
+	"strings"
+)
+
+type PostmanCollection struct {
+	Info     PostmanInfo       `json:"info"`
+	Item     []PostmanItem     `json:"item"`
+	Variable []PostmanVariable `json:"variable"`
+}
+
+type PostmanInfo struct {
+	Name   string `json:"name"`
+	Schema string `json:"schema"`
+}
+
+type PostmanItem struct {
+	Name    string         `json:"name"`
+	Request PostmanRequest `json:"request"`
+}
+
+type PostmanRequest struct {
+	Method      string          `json:"method"`
+	Header      []PostmanHeader `json:"header"`
+	Body        PostmanBody     `json:"body"`
+	Url         PostmanUrl      `json:"url"`
+	Description string          `json:"description,omitempty"`
+}
+
+type PostmanHeader struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type PostmanBody struct {
+	Mode string `json:"mode"`
+	Raw  string `json:"raw"`
+}
+
+type PostmanUrl struct {
+	Raw      string            `json:"raw"`
+	Host     []string          `json:"host"`
+	Path     []string          `json:"path"`
+	Query    []PostmanVariable `json:"query,omitempty"`
+	Variable []PostmanVariable `json:"variable,omitempty"`
+}
+
+type PostmanVariable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type InsomniaExport struct {
+	Type         string             `json:"_type"`
+	ExportFormat int                `json:"__export_format"`
+	ExportSource string             `json:"__export_source"`
+	Resources    []InsomniaResource `json:"resources"`
+}
+
+// Insomnia exports are a flat list of resources of mixed types, so the fields
+// used by only one type are omitted when empty.
+type InsomniaResource struct {
+	Id          string            `json:"_id"`
+	Type        string            `json:"_type"`
+	ParentId    string            `json:"parentId,omitempty"`
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Method      string            `json:"method,omitempty"`
+	Url         string            `json:"url,omitempty"`
+	Body        *InsomniaBody     `json:"body,omitempty"`
+	Parameters  []InsomniaHeader  `json:"parameters,omitempty"`
+	Headers     []InsomniaHeader  `json:"headers,omitempty"`
+	Data        map[string]string `json:"data,omitempty"`
+}
+
+type InsomniaBody struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type InsomniaHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+const postmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+const (
+	insomniaWorkspaceId   = "wrk_atoz"
+	insomniaEnvironmentId = "env_atoz"
+)
+
+func GeneratePostman(apiSpec ApiSpec, title string, baseUrl string) (PostmanCollection, error) {
+	collection := PostmanCollection{
+		PostmanInfo{title, postmanSchema},
+		make([]PostmanItem, 0),
+		[]PostmanVariable{
+			PostmanVariable{"baseUrl", baseUrl},
+		},
+	}
+
+	objects := ObjectsByRef(apiSpec.Objects)
+
+	for _, action := range apiSpec.Actions {
+		body, err := ExampleRequestBody(action, objects)
+
+		if err != nil {
+			return collection, err
+		}
+
+		headers := []PostmanHeader{
+			PostmanHeader{"Content-Type", "application/json"},
+		}
+
+		for _, parameter := range LocationParameters(action.Parameters, "header") {
+			headers = append(headers, PostmanHeader{parameter.Name, ExampleLocationValue(parameter)})
+		}
+
+		collection.Item = append(collection.Item, PostmanItem{
+			action.Name,
+			PostmanRequest{
+				ActionMethod(action),
+				headers,
+				PostmanBody{"raw", body},
+				GeneratePostmanUrl(action),
+				ActionDescription(action),
+			},
+		})
+	}
+
+	return collection, nil
+}
+
+// Path parameters become :name variables, named as the parameter is declared,
+// and query parameters are filled in with example values.
+func GeneratePostmanUrl(action Action) PostmanUrl {
+	path := uriPlaceholder.ReplaceAllStringFunc(action.Uri, func(placeholder string) string {
+		if parameter := FindKeyValue(LocationParameters(action.Parameters, "path"), placeholder[1:len(placeholder)-1]); parameter != nil {
+			return ":" + parameter.Name
+		}
+
+		return ":" + placeholder[1:len(placeholder)-1]
+	})
+
+	url := PostmanUrl{
+		Raw:  "{{baseUrl}}" + path,
+		Host: []string{"{{baseUrl}}"},
+		Path: strings.Split(strings.Trim(path, "/"), "/"),
+	}
+
+	for _, parameter := range LocationParameters(action.Parameters, "path") {
+		url.Variable = append(url.Variable, PostmanVariable{parameter.Name, ExampleLocationValue(parameter)})
+	}
+
+	query := make([]string, 0)
+
+	for _, parameter := range LocationParameters(action.Parameters, "query") {
+		url.Query = append(url.Query, PostmanVariable{parameter.Name, ExampleLocationValue(parameter)})
+		query = append(query, parameter.Name+"="+ExampleLocationValue(parameter))
+	}
+
+	if len(query) > 0 {
+		url.Raw += "?" + strings.Join(query, "&")
+	}
+
+	return url
+}
+
+func GenerateInsomnia(apiSpec ApiSpec, title string, baseUrl string) (InsomniaExport, error) {
+	export := InsomniaExport{
+		"export",
+		4,
+		"atoz",
+		[]InsomniaResource{
+			InsomniaResource{
+				Id:   insomniaWorkspaceId,
+				Type: "workspace",
+				Name: title,
+			},
+			InsomniaResource{
+				Id:       insomniaEnvironmentId,
+				Type:     "environment",
+				ParentId: insomniaWorkspaceId,
+				Name:     "Base Environment",
+				Data:     map[string]string{"baseUrl": baseUrl},
+			},
+		},
+	}
+
+	objects := ObjectsByRef(apiSpec.Objects)
+
+	for _, action := range apiSpec.Actions {
+		body, err := ExampleRequestBody(action, objects)
+
+		if err != nil {
+			return export, err
+		}
+
+		parameters := make([]InsomniaHeader, 0)
+
+		for _, parameter := range LocationParameters(action.Parameters, "query") {
+			parameters = append(parameters, InsomniaHeader{parameter.Name, ExampleLocationValue(parameter)})
+		}
+
+		headers := []InsomniaHeader{
+			InsomniaHeader{"Content-Type", "application/json"},
+		}
+
+		for _, parameter := range LocationParameters(action.Parameters, "header") {
+			headers = append(headers, InsomniaHeader{parameter.Name, ExampleLocationValue(parameter)})
+		}
+
+		// Insomnia has no path variables, so placeholders are filled in.
+		uri := uriPlaceholder.ReplaceAllStringFunc(action.Uri, func(placeholder string) string {
+			if parameter := FindKeyValue(LocationParameters(action.Parameters, "path"), placeholder[1:len(placeholder)-1]); parameter != nil {
+				return ExampleLocationValue(*parameter)
+			}
+
+			return placeholder
+		})
+
+		export.Resources = append(export.Resources, InsomniaResource{
+			Id:          "req_" + strings.ToLower(SchemaName(action.Ref)),
+			Type:        "request",
+			ParentId:    insomniaWorkspaceId,
+			Name:        action.Name,
+			Description: ActionDescription(action),
+			Method:      ActionMethod(action),
+			Url:         "{{ _.baseUrl }}" + uri,
+			Body:        &InsomniaBody{"application/json", body},
+			Parameters:  parameters,
+			Headers:     headers,
+		})
+	}
+
+	return export, nil
+}
@@ -0,0 +1,84 @@
+package atoz
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+var testPostmanApiSpec = ApiSpec{
+	Actions: []Action{
+		Action{
+			Name:   "User Lookup",
+			Ref:    "/MyApp/User/Lookup",
+			Method: "POST",
+			Uri:    "/api/user/lookup",
+			Notes:  []string{},
+			Parameters: []KeyValue{
+				KeyValue{Name: "auth", Flag: "required", Type: "object", Limit: -1, Children: []KeyValue{
+					KeyValue{Name: "id", Flag: "required", Type: "integer", Limit: -1, Children: []KeyValue{}},
+				}},
+				KeyValue{Name: "ids", Type: "array", Children: []KeyValue{
+					KeyValue{Name: "id", Type: "integer", Limit: -1, Children: []KeyValue{}},
+				}},
+			},
+			Returns: []KeyValue{},
+		},
+	},
+	Objects: []Object{},
+}
+
+func TestGeneratePostman(t *testing.T) {
+	collection, err := GeneratePostman(testPostmanApiSpec, "Test", "http://localhost:8080")
+
+	if err != nil {
+		t.Errorf("TestGeneratePostman Unexpected error: %s", err)
+		return
+	}
+
+	if len(collection.Item) != 1 {
+		t.Errorf("TestGeneratePostman Expected 1 item, got %d", len(collection.Item))
+		return
+	}
+
+	request := collection.Item[0].Request
+
+	if request.Url.Raw != "{{baseUrl}}/api/user/lookup" ||
+		!reflect.DeepEqual(request.Url.Path, []string{"api", "user", "lookup"}) {
+		t.Errorf("TestGeneratePostman Url Mismatch: %+v", request.Url)
+	}
+
+	var body interface{}
+
+	if err = json.Unmarshal([]byte(request.Body.Raw), &body); err != nil {
+		t.Errorf("TestGeneratePostman Invalid body: %s", err)
+		return
+	}
+
+	expected := map[string]interface{}{
+		"auth": map[string]interface{}{"id": 1.0},
+		"ids":  []interface{}{map[string]interface{}{"id": 1.0}},
+	}
+
+	if !reflect.DeepEqual(body, expected) {
+		t.Errorf("TestGeneratePostman Body Mismatch\nExpected: %s\n  Actual: %s", expected, body)
+	}
+}
+
+func TestGenerateInsomnia(t *testing.T) {
+	export, err := GenerateInsomnia(testPostmanApiSpec, "Test", "http://localhost:8080")
+
+	if err != nil {
+		t.Errorf("TestGenerateInsomnia Unexpected error: %s", err)
+		return
+	}
+
+	if len(export.Resources) != 3 {
+		t.Errorf("TestGenerateInsomnia Expected 3 resources, got %d", len(export.Resources))
+		return
+	}
+
+	if export.Resources[2].Url != "{{ _.baseUrl }}/api/user/lookup" {
+		t.Errorf("TestGenerateInsomnia Url Mismatch: %s", export.Resources[2].Url)
+	}
+}
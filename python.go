@@ -0,0 +1,438 @@
+package atoz
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var pythonKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true,
+	"assert": true, "async": true, "await": true, "break": true, "class": true,
+	"continue": true, "def": true, "del": true, "elif": true, "else": true,
+	"except": true, "finally": true, "for": true, "from": true, "global": true,
+	"if": true, "import": true, "in": true, "is": true, "lambda": true,
+	"nonlocal": true, "not": true, "or": true, "pass": true, "raise": true,
+	"return": true, "try": true, "while": true, "with": true, "yield": true,
+}
+
+// Renders a Python module with a dataclass for every object, a Request and
+// Response dataclass for every action, named the same way as the Go structs,
+// and a requests based Client with a method for every action with a uri.
+func GeneratePython(apiSpec ApiSpec, title string) []byte {
+	generator := pythonGenerator{ObjectsByRef(apiSpec.Objects), make([]string, 0)}
+
+	for _, object := range apiSpec.Objects {
+		summary := []string{"The " + object.Name + " object."}
+
+		if object.Deprecated {
+			summary = append(summary, "Deprecated.")
+		}
+
+		generator.dataclass(TypeName(object.Ref), append(summary, ObjectDescription(object)), object.Properties)
+	}
+
+	methods := make([]string, 0)
+
+	for _, action := range apiSpec.Actions {
+		name := TypeName(action.Ref)
+		actionTitle := action.Name
+
+		if len(actionTitle) == 0 {
+			actionTitle = action.Ref
+		}
+
+		summary := []string{"The body of the " + actionTitle + " request."}
+
+		if len(LocationParameters(action.Parameters, "")) < len(action.Parameters) {
+			summary = append(summary, "Path, query and header parameters are sent apart from the JSON body.")
+		}
+
+		generator.dataclass(name+"Request", summary, action.Parameters)
+		generator.dataclass(name+"Response", []string{"The response to the " + actionTitle + " request."}, ResponseReturns(action))
+
+		if len(action.Uri) > 0 {
+			methods = append(methods, pythonClientMethod(action, actionTitle))
+		}
+	}
+
+	python := "\"\"\"Client for " + pythonDocstringText(title) + ", generated by atoz.  Do not edit.\"\"\"\n\n" +
+		pythonClientSource +
+		strings.Join(generator.classes, "\n\n") +
+		"\n\n" + pythonClientClass +
+		strings.Join(methods, "")
+
+	return []byte(python)
+}
+
+// Collects the dataclasses in the order they're found, with each nested
+// dataclass following the one it's declared in.
+type pythonGenerator struct {
+	objects map[string]Object
+	classes []string
+}
+
+// Values without a default have to come first, so optional values are moved
+// after the rest.
+func (g *pythonGenerator) dataclass(name string, summary []string, keyValues []KeyValue) {
+	index := len(g.classes)
+	g.classes = append(g.classes, "")
+
+	ordered := make([]KeyValue, len(keyValues))
+	copy(ordered, keyValues)
+
+	sort.Stable(KeyValueByRequired(ordered))
+
+	fields := ""
+	attributes := make([]string, 0)
+
+	for _, keyValue := range ordered {
+		fieldName := PythonName(keyValue.Name)
+		fieldType := g.fieldType(name, keyValue)
+
+		metadata := make([]string, 0)
+
+		if fieldName != keyValue.Name {
+			metadata = append(metadata, "\"json\": "+strconv.Quote(keyValue.Name))
+		}
+
+		if len(keyValue.Location) > 0 {
+			metadata = append(metadata, "\"location\": "+strconv.Quote(keyValue.Location))
+		}
+
+		options := make([]string, 0)
+
+		if isOptional(keyValue) {
+			fieldType = "Optional[" + fieldType + "]"
+			options = append(options, "default=None")
+		}
+
+		if len(metadata) > 0 {
+			options = append(options, "metadata={"+strings.Join(metadata, ", ")+"}")
+		}
+
+		fields += "    " + fieldName + ": " + fieldType
+
+		if len(options) > 0 {
+			fields += " = field(" + strings.Join(options, ", ") + ")"
+		}
+
+		fields += "\n"
+
+		hint := strings.TrimSpace(strings.Join(strings.Fields(keyValue.Description), " ") + " " + LimitHint(keyValue))
+
+		if len(hint) > 0 {
+			attributes = append(attributes, fieldName+": "+hint)
+		}
+	}
+
+	if len(fields) == 0 {
+		fields = "    pass\n"
+	}
+
+	docstring := make([]string, 0)
+
+	for _, paragraph := range summary {
+		if paragraph = strings.TrimSpace(paragraph); len(paragraph) > 0 {
+			docstring = append(docstring, paragraph)
+		}
+	}
+
+	if len(attributes) > 0 {
+		docstring = append(docstring, "Attributes:\n    "+strings.Join(attributes, "\n    "))
+	}
+
+	g.classes[index] = "@dataclass\n" +
+		"class " + name + ":\n" +
+		pythonDocstring(strings.Join(docstring, "\n\n"), "    ") + "\n" +
+		fields
+}
+
+// Puts key/values that are always sent before optional ones, keeping the
+// order of each.
+type KeyValueByRequired []KeyValue
+
+func (a KeyValueByRequired) Len() int      { return len(a) }
+func (a KeyValueByRequired) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a KeyValueByRequired) Less(i, j int) bool {
+	return !isOptional(a[i]) && isOptional(a[j])
+}
+
+// Objects and arrays with children become a dataclass named after the one
+// they're in and their own name.
+func (g *pythonGenerator) fieldType(parent string, keyValue KeyValue) string {
+	if ref, ok := TypeRef(keyValue.Type); ok {
+		if _, ok := g.objects[ref]; ok {
+			return TypeName(ref)
+		}
+
+		return "Any"
+	}
+
+	switch keyValue.Type {
+	case "boolean":
+		return "bool"
+	case "integer":
+		return "int"
+	case "decimal":
+		return "float"
+	case "string":
+		return "str"
+	case "object":
+		if len(keyValue.Children) == 0 {
+			return "Dict[str, Any]"
+		}
+
+		className := parent + GoFieldName(keyValue.Name)
+		g.dataclass(className, []string{"The " + keyValue.Name + " value of " + parent + "."}, keyValue.Children)
+
+		return className
+	case "array":
+		if len(keyValue.Children) == 0 {
+			return "List[Any]"
+		}
+
+		className := parent + GoFieldName(keyValue.Name)
+		g.dataclass(className, []string{"An element of the " + keyValue.Name + " value of " + parent + "."}, keyValue.Children)
+
+		return "List[" + className + "]"
+	}
+
+	return "Any"
+}
+
+// Receive a key/value
+// Return a sentence describing its limit, or nothing if it has none.
+func LimitHint(keyValue KeyValue) string {
+	if keyValue.Limit <= 0 {
+		return ""
+	}
+
+	switch keyValue.Type {
+	case "string":
+		return fmt.Sprintf("At most %d characters.", keyValue.Limit)
+	case "decimal":
+		return fmt.Sprintf("At most %d decimal places.", keyValue.Limit)
+	case "array":
+		return fmt.Sprintf("At most %d elements.", keyValue.Limit)
+	}
+
+	return ""
+}
+
+// Receive
+// MyAppUserLookup or first-name
+// Return my_app_user_lookup or first_name
+func PythonName(name string) string {
+	runes := []rune(name)
+	snake := make([]rune, 0)
+
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			r = '_'
+		}
+
+		// Start a new word at an upper case letter that follows a lower case
+		// letter or digit, or that ends a run of upper case letters.
+		if unicode.IsUpper(r) && i > 0 && len(snake) > 0 && snake[len(snake)-1] != '_' &&
+			(!unicode.IsUpper(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			snake = append(snake, '_')
+		}
+
+		snake = append(snake, unicode.ToLower(r))
+	}
+
+	pythonName := strings.Trim(string(snake), "_")
+
+	if len(pythonName) == 0 || unicode.IsDigit([]rune(pythonName)[0]) {
+		pythonName = "field_" + pythonName
+	}
+
+	if pythonKeywords[pythonName] {
+		pythonName += "_"
+	}
+
+	return pythonName
+}
+
+func pythonClientMethod(action Action, actionTitle string) string {
+	name := TypeName(action.Ref)
+
+	method := ActionMethod(action)
+
+	path := make([]string, 0)
+	last := 0
+
+	for _, match := range uriPlaceholder.FindAllStringSubmatchIndex(action.Uri, -1) {
+		parameter := FindKeyValue(LocationParameters(action.Parameters, "path"), action.Uri[match[2]:match[3]])
+
+		if parameter == nil {
+			continue
+		}
+
+		path = append(path, strconv.Quote(action.Uri[last:match[0]]), "quote(str(request."+PythonName(parameter.Name)+"), safe=\"\")")
+		last = match[1]
+	}
+
+	path = append(path, strconv.Quote(action.Uri[last:]))
+
+	body := "        path = " + strings.Join(path, " + ") + "\n"
+
+	for _, location := range []string{"query", "header"} {
+		values := make([]string, 0)
+
+		for _, parameter := range LocationParameters(action.Parameters, location) {
+			values = append(values, strconv.Quote(parameter.Name)+": request."+PythonName(parameter.Name))
+		}
+
+		body += "        " + location + " = {" + strings.Join(values, ", ") + "}\n"
+	}
+
+	requestBody := "None"
+
+	if len(LocationParameters(action.Parameters, "")) > 0 {
+		requestBody = "request"
+	}
+
+	docstring := "Sends the " + actionTitle + " request."
+
+	if description := ActionDescription(action); len(description) > 0 {
+		docstring += "\n\n" + description
+	}
+
+	if action.Deprecated {
+		docstring += "\n\nDeprecated."
+	}
+
+	docstring += "\n\nRaises ApiError when the status isn't 2xx."
+
+	return "\n    def " + PythonName(name) + "(self, request: " + name + "Request) -> " + name + "Response:\n" +
+		pythonDocstring(docstring, "        ") +
+		body +
+		"        return self._request(" + strconv.Quote(strings.ToUpper(method)) + ", path, query, header, " + requestBody + ", " + name + "Response)\n"
+}
+
+func pythonDocstring(text string, indent string) string {
+	lines := strings.Split(pythonDocstringText(text), "\n")
+
+	if len(lines) == 1 {
+		return indent + "\"\"\"" + lines[0] + "\"\"\"\n"
+	}
+
+	docstring := indent + "\"\"\"" + lines[0] + "\n"
+
+	for _, line := range lines[1:] {
+		docstring += strings.TrimRight(indent+line, " ") + "\n"
+	}
+
+	return docstring + indent + "\"\"\"\n"
+}
+
+func pythonDocstringText(text string) string {
+	text = strings.Replace(text, "\\", "\\\\", -1)
+
+	return strings.Replace(text, "\"\"\"", "\\\"\\\"\\\"", -1)
+}
+
+const pythonClientSource = `from __future__ import annotations
+
+import dataclasses
+import typing
+from dataclasses import dataclass, field
+from typing import Any, Dict, List, Optional
+from urllib.parse import quote
+
+import requests
+
+
+class ApiError(Exception):
+    """Raised when the API responds with a status that isn't 2xx.
+
+    The response is decoded the same way as a successful one, so failure
+    values can be read from it.
+    """
+
+    def __init__(self, status_code: int, response: Any):
+        super().__init__("API responded with %d" % status_code)
+        self.status_code = status_code
+        self.response = response
+
+
+def _to_json(value: Any) -> Any:
+    """Converts dataclasses to dicts keyed by their JSON names.
+
+    Path, query and header parameters, and optional values that are None,
+    are left out.
+    """
+    if dataclasses.is_dataclass(value):
+        result = {}
+
+        for f in dataclasses.fields(value):
+            item = getattr(value, f.name)
+
+            if f.metadata.get("location") or (item is None and f.default is None):
+                continue
+
+            result[f.metadata.get("json", f.name)] = _to_json(item)
+
+        return result
+
+    if isinstance(value, list):
+        return [_to_json(item) for item in value]
+
+    return value
+
+
+def _from_json(hint: Any, value: Any) -> Any:
+    """Converts decoded JSON to the type hint, building dataclasses from dicts."""
+    if value is None:
+        return None
+
+    if typing.get_origin(hint) is typing.Union:
+        hint = [arg for arg in typing.get_args(hint) if arg is not type(None)][0]
+
+    if typing.get_origin(hint) is list and isinstance(value, list):
+        return [_from_json(typing.get_args(hint)[0], item) for item in value]
+
+    if dataclasses.is_dataclass(hint) and isinstance(value, dict):
+        hints = typing.get_type_hints(hint)
+        values = {}
+
+        for f in dataclasses.fields(hint):
+            values[f.name] = _from_json(hints[f.name], value.get(f.metadata.get("json", f.name)))
+
+        return hint(**values)
+
+    return value
+
+
+`
+
+const pythonClientClass = `class Client:
+    """Sends requests to the API at base_url."""
+
+    def __init__(self, base_url: str, headers: Optional[Dict[str, str]] = None, timeout: float = 30, session: Optional[requests.Session] = None):
+        self.base_url = base_url.rstrip("/")
+        self.timeout = timeout
+        self.session = session or requests.Session()
+        self.session.headers.update(headers or {})
+
+    def _request(self, method: str, path: str, query: Dict[str, Any], header: Dict[str, Any], body: Any, response_type: Any) -> Any:
+        response = self.session.request(
+            method,
+            self.base_url + path,
+            params={name: value for name, value in query.items() if value is not None},
+            headers={name: str(value) for name, value in header.items() if value is not None},
+            json=None if body is None else _to_json(body),
+            timeout=self.timeout,
+        )
+
+        result = _from_json(response_type, response.json() if response.content else {})
+
+        if not 200 <= response.status_code < 300:
+            raise ApiError(response.status_code, result)
+
+        return result
+`
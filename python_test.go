@@ -0,0 +1,133 @@
+package atoz
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGeneratePython(t *testing.T) {
+	apiSpec := ApiSpec{
+		Actions: []Action{
+			Action{Name: "Update User", Ref: "/MyApp/User/Update", Method: "PUT", Uri: "/users/{id}/profile", Description: "Update a user.", Notes: []string{}, Parameters: []KeyValue{
+				KeyValue{Name: "id", Flag: "required", Type: "integer", Limit: -1, Children: []KeyValue{}, Location: "path"},
+				KeyValue{Name: "fields", Flag: "optional", Type: "string", Children: []KeyValue{}, Location: "query"},
+				KeyValue{Name: "page", Type: "integer", Limit: -1, Children: []KeyValue{}, Location: "query"},
+				KeyValue{Name: "x-token", Flag: "required", Type: "string", Children: []KeyValue{}, Location: "header"},
+				KeyValue{Name: "score", Flag: "optional", Type: "decimal", Limit: 2, Children: []KeyValue{}},
+				KeyValue{Name: "auth", Flag: "required", Type: "object", Limit: -1, Children: []KeyValue{
+					KeyValue{Name: "key", Flag: "required", Type: "string", Limit: 64, Description: "The key.", Children: []KeyValue{}},
+				}},
+			}, Returns: []KeyValue{
+				KeyValue{Name: "user", Flag: "success", Type: "#/Application/User#", Limit: -1, Children: []KeyValue{}, ResolvedRef: "/Application/User"},
+			}, Responses: map[string]Response{
+				"201": Response{Returns: []KeyValue{
+					KeyValue{Name: "created", Flag: "success", Type: "integer", Limit: -1, Children: []KeyValue{}},
+				}},
+			}},
+			Action{Name: "Internal", Ref: "/MyApp/Internal", Method: "POST", Notes: []string{}, Parameters: []KeyValue{}, Returns: []KeyValue{}},
+		},
+		Objects: []Object{
+			Object{Name: "User", Ref: "/Application/User", Notes: []string{}, Properties: []KeyValue{
+				KeyValue{Name: "first-name", Type: "string", Children: []KeyValue{}},
+				KeyValue{Name: "manager", Type: "#/Application/User#", Limit: -1, Children: []KeyValue{}, ResolvedRef: "/Application/User"},
+				KeyValue{Name: "groups", Type: "array", Limit: 10, Description: "The groups of the user.", Children: []KeyValue{
+					KeyValue{Name: "class", Type: "string", Children: []KeyValue{}},
+				}},
+			}, Deprecated: true},
+		},
+	}
+
+	python := string(GeneratePython(apiSpec, "Test"))
+
+	expected := []string{
+		"\"\"\"Client for Test, generated by atoz.  Do not edit.\"\"\"\n",
+		"@dataclass\nclass ApplicationUser:\n" +
+			"    \"\"\"The User object.\n\n" +
+			"    Deprecated.\n\n" +
+			"    Attributes:\n" +
+			"        groups: The groups of the user. At most 10 elements.\n" +
+			"    \"\"\"\n\n" +
+			"    first_name: str = field(metadata={\"json\": \"first-name\"})\n" +
+			"    manager: ApplicationUser\n" +
+			"    groups: List[ApplicationUserGroups]\n",
+		"class ApplicationUserGroups:\n" +
+			"    \"\"\"An element of the groups value of ApplicationUser.\"\"\"\n\n" +
+			"    class_: str = field(metadata={\"json\": \"class\"})\n",
+		"class MyAppUserUpdateRequest:\n" +
+			"    \"\"\"The body of the Update User request.\n\n" +
+			"    Path, query and header parameters are sent apart from the JSON body.\n\n" +
+			"    Attributes:\n" +
+			"        score: At most 2 decimal places.\n" +
+			"    \"\"\"\n\n" +
+			"    id: int = field(metadata={\"location\": \"path\"})\n" +
+			"    x_token: str = field(metadata={\"json\": \"x-token\", \"location\": \"header\"})\n" +
+			"    auth: MyAppUserUpdateRequestAuth\n" +
+			"    fields: Optional[str] = field(default=None, metadata={\"location\": \"query\"})\n" +
+			"    page: Optional[int] = field(default=None, metadata={\"location\": \"query\"})\n" +
+			"    score: Optional[float] = field(default=None)\n",
+		"        key: The key. At most 64 characters.\n",
+		"class MyAppUserUpdateResponse:\n" +
+			"    \"\"\"The response to the Update User request.\"\"\"\n\n" +
+			"    user: Optional[ApplicationUser] = field(default=None)\n" +
+			"    created: Optional[int] = field(default=None)\n",
+		"class MyAppInternalRequest:\n" +
+			"    \"\"\"The body of the Internal request.\"\"\"\n\n" +
+			"    pass\n",
+		"    def my_app_user_update(self, request: MyAppUserUpdateRequest) -> MyAppUserUpdateResponse:\n" +
+			"        \"\"\"Sends the Update User request.\n\n" +
+			"        Update a user.\n\n" +
+			"        Raises ApiError when the status isn't 2xx.\n" +
+			"        \"\"\"\n" +
+			"        path = \"/users/\" + quote(str(request.id), safe=\"\") + \"/profile\"\n" +
+			"        query = {\"fields\": request.fields, \"page\": request.page}\n" +
+			"        header = {\"x-token\": request.x_token}\n" +
+			"        return self._request(\"PUT\", path, query, header, request, MyAppUserUpdateResponse)\n",
+	}
+
+	for _, snippet := range expected {
+		if !strings.Contains(python, snippet) {
+			t.Errorf("TestGeneratePython Missing:\n%s\nFrom:\n%s", snippet, python)
+		}
+	}
+
+	if strings.Contains(python, "def my_app_internal") {
+		t.Errorf("TestGeneratePython Unexpected method for an action without a uri")
+	}
+}
+
+func TestPythonName(t *testing.T) {
+	cases := map[string]string{
+		"MyAppUserLookup": "my_app_user_lookup",
+		"first-name":      "first_name",
+		"userId":          "user_id",
+		"XToken":          "x_token",
+		"HTTPServer":      "http_server",
+		"class":           "class_",
+		"2fa":             "field_2fa",
+	}
+
+	for name, expected := range cases {
+		if pythonName := PythonName(name); pythonName != expected {
+			t.Errorf("TestPythonName Mismatch: %s => %s, expected %s", name, pythonName, expected)
+		}
+	}
+}
+
+func TestLimitHint(t *testing.T) {
+	cases := []struct {
+		keyValue KeyValue
+		expected string
+	}{
+		{KeyValue{Name: "a", Type: "string", Limit: 64, Children: []KeyValue{}}, "At most 64 characters."},
+		{KeyValue{Name: "a", Type: "decimal", Limit: 2, Children: []KeyValue{}}, "At most 2 decimal places."},
+		{KeyValue{Name: "a", Type: "array", Limit: 5, Children: []KeyValue{}}, "At most 5 elements."},
+		{KeyValue{Name: "a", Type: "string", Children: []KeyValue{}}, ""},
+		{KeyValue{Name: "a", Type: "integer", Limit: -1, Children: []KeyValue{}}, ""},
+	}
+
+	for _, c := range cases {
+		if hint := LimitHint(c.keyValue); hint != c.expected {
+			t.Errorf("TestLimitHint Mismatch: %s, expected %s", hint, c.expected)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package atoz
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type SarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+
+	// Columns are counted in runes, where SARIF counts UTF-16 code units
+	// unless it's told otherwise.
+	ColumnKind string `json:"columnKind"`
+}
+
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifDriver struct {
+	Name           string      `json:"name"`
+	InformationUri string      `json:"informationUri"`
+	Rules          []SarifRule `json:"rules"`
+}
+
+type SarifRule struct {
+	Id               string       `json:"id"`
+	ShortDescription SarifMessage `json:"shortDescription"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifResult struct {
+	RuleId    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   SarifMessage    `json:"message"`
+	Locations []SarifLocation `json:"locations,omitempty"`
+}
+
+type SarifLocation struct {
+	PhysicalLocation SarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+	Region           *SarifRegion          `json:"region,omitempty"`
+}
+
+type SarifArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+type SarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// Receive the diagnostics of a run and a format - text, json or sarif
+// Return the report to write.  Text reports are blank when there's nothing to
+// report, the others always describe the run.
+func FormatDiagnostics(diagnostics Diagnostics, format string) ([]byte, error) {
+	switch format {
+	case "text":
+		if len(diagnostics) == 0 {
+			return []byte{}, nil
+		}
+
+		return []byte(diagnostics.Error() + "\n"), nil
+	case "json":
+		if diagnostics == nil {
+			diagnostics = Diagnostics{}
+		}
+
+		return marshalReport(diagnostics)
+	case "sarif":
+		return marshalReport(GenerateSarif(diagnostics))
+	}
+
+	return nil, fmt.Errorf("Unknown diagnostics format: %s", format)
+}
+
+func marshalReport(report interface{}) ([]byte, error) {
+	contents, err := json.MarshalIndent(report, "", "  ")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(contents, '\n'), nil
+}
+
+// Every parser code and lint rule is listed as a rule, so results can be
+// linked to a description.
+func GenerateSarif(diagnostics Diagnostics) SarifLog {
+	driver := SarifDriver{"atoz", "https://github.com/funnylookinhat/atoz", make([]SarifRule, 0)}
+
+	codes := make([]string, 0)
+
+	for code, _ := range codeDescriptions {
+		codes = append(codes, code)
+	}
+
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		driver.Rules = append(driver.Rules, SarifRule{code, SarifMessage{codeDescriptions[code]}})
+	}
+
+	for _, rule := range LintRules {
+		if _, ok := codeDescriptions[rule.Id]; !ok {
+			driver.Rules = append(driver.Rules, SarifRule{rule.Id, SarifMessage{rule.Description}})
+		}
+	}
+
+	run := SarifRun{SarifTool{driver}, make([]SarifResult, 0), "unicodeCodePoints"}
+
+	for _, diagnostic := range diagnostics {
+		result := SarifResult{
+			diagnostic.Code,
+			diagnostic.Severity,
+			SarifMessage{diagnostic.Message},
+			nil,
+		}
+
+		if len(diagnostic.File) > 0 {
+			location := SarifLocation{SarifPhysicalLocation{SarifArtifactLocation{SarifUri(diagnostic.File)}, nil}}
+
+			if diagnostic.Line > 0 {
+				location.PhysicalLocation.Region = &SarifRegion{diagnostic.Line, diagnostic.Column}
+			}
+
+			result.Locations = append(result.Locations, location)
+		}
+
+		run.Results = append(run.Results, result)
+	}
+
+	return SarifLog{sarifVersion, sarifSchema, []SarifRun{run}}
+}
+
+// Relative paths are kept relative so they resolve against the checkout, and
+// absolute paths become file:// URIs.
+func SarifUri(path string) string {
+	uri := filepath.ToSlash(filepath.Clean(path))
+
+	if filepath.IsAbs(path) {
+		if !strings.HasPrefix(uri, "/") {
+			uri = "/" + uri
+		}
+
+		return "file://" + uri
+	}
+
+	return uri
+}
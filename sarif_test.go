@@ -0,0 +1,93 @@
+package atoz
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+var testSarifDiagnostics = Diagnostics{
+	Diagnostic{File: "src/user.js", Line: 12, Column: 4, Severity: SeverityError, Code: CodeInvalidLine, Message: "Invalid type: foo"},
+	Diagnostic{File: "src/user.js", Line: 2, Column: 4, Severity: SeverityWarning, Code: "missing-uri", Message: "Action /X has no @uri"},
+	Diagnostic{File: "/abs/user.js", Severity: SeverityError, Code: CodeUnreadableFile, Message: "permission denied"},
+}
+
+func TestGenerateSarif(t *testing.T) {
+	sarif := GenerateSarif(testSarifDiagnostics)
+
+	if sarif.Version != "2.1.0" || len(sarif.Runs) != 1 || sarif.Runs[0].ColumnKind != "unicodeCodePoints" {
+		t.Errorf("TestGenerateSarif Log Mismatch: %+v", sarif)
+		return
+	}
+
+	run := sarif.Runs[0]
+
+	rules := make(map[string]bool)
+
+	for _, rule := range run.Tool.Driver.Rules {
+		rules[rule.Id] = true
+	}
+
+	for _, id := range []string{CodeInvalidLine, CodeUnknownRef, "missing-uri", "orphan-child"} {
+		if !rules[id] {
+			t.Errorf("TestGenerateSarif Missing rule: %s", id)
+		}
+	}
+
+	expected := []SarifResult{
+		SarifResult{
+			RuleId:  CodeInvalidLine,
+			Level:   "error",
+			Message: SarifMessage{Text: "Invalid type: foo"},
+			Locations: []SarifLocation{
+				SarifLocation{PhysicalLocation: SarifPhysicalLocation{ArtifactLocation: SarifArtifactLocation{Uri: "src/user.js"}, Region: &SarifRegion{StartLine: 12, StartColumn: 4}}},
+			},
+		},
+		SarifResult{
+			RuleId:  "missing-uri",
+			Level:   "warning",
+			Message: SarifMessage{Text: "Action /X has no @uri"},
+			Locations: []SarifLocation{
+				SarifLocation{PhysicalLocation: SarifPhysicalLocation{ArtifactLocation: SarifArtifactLocation{Uri: "src/user.js"}, Region: &SarifRegion{StartLine: 2, StartColumn: 4}}},
+			},
+		},
+		SarifResult{
+			RuleId:  CodeUnreadableFile,
+			Level:   "error",
+			Message: SarifMessage{Text: "permission denied"},
+			Locations: []SarifLocation{
+				SarifLocation{PhysicalLocation: SarifPhysicalLocation{ArtifactLocation: SarifArtifactLocation{Uri: "file:///abs/user.js"}}},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(run.Results, expected) {
+		t.Errorf("TestGenerateSarif Results Mismatch:\nExpected: %+v\n  Actual: %+v", expected, run.Results)
+	}
+}
+
+func TestFormatDiagnostics(t *testing.T) {
+	text, err := FormatDiagnostics(nil, "text")
+
+	if err != nil || len(text) != 0 {
+		t.Errorf("TestFormatDiagnostics Text should be blank without diagnostics: %q", text)
+	}
+
+	report, err := FormatDiagnostics(nil, "json")
+
+	if err != nil || string(report) != "[]\n" {
+		t.Errorf("TestFormatDiagnostics Json Mismatch: %q", report)
+	}
+
+	report, err = FormatDiagnostics(testSarifDiagnostics, "json")
+
+	var decoded Diagnostics
+
+	if err != nil || json.Unmarshal(report, &decoded) != nil || !reflect.DeepEqual(decoded, testSarifDiagnostics) {
+		t.Errorf("TestFormatDiagnostics Json Mismatch: %s", report)
+	}
+
+	if _, err = FormatDiagnostics(nil, "xml"); err == nil {
+		t.Errorf("TestFormatDiagnostics - Should have errored out on an unknown format")
+	}
+}
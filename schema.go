@@ -0,0 +1,139 @@
+package atoz
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+type Schema struct {
+	SchemaVersion string             `json:"$schema,omitempty"`
+	Ref           string             `json:"$ref,omitempty"`
+	Title         string             `json:"title,omitempty"`
+	Type          string             `json:"type,omitempty"`
+	Description   string             `json:"description,omitempty"`
+	Properties    map[string]*Schema `json:"properties,omitempty"`
+	Required      []string           `json:"required,omitempty"`
+	Items         *Schema            `json:"items,omitempty"`
+	MaxLength     *int64             `json:"maxLength,omitempty"`
+	MaxItems      *int64             `json:"maxItems,omitempty"`
+	MultipleOf    *float64           `json:"multipleOf,omitempty"`
+}
+
+var schemaTypes = map[string]string{
+	"boolean": "boolean",
+	"integer": "integer",
+	"decimal": "number",
+	"string":  "string",
+	"array":   "array",
+	"object":  "object",
+}
+
+// Receive
+// #/Application/User#
+// Return /Application/User, true
+func TypeRef(keyValueType string) (string, bool) {
+	if len(keyValueType) < 2 ||
+		keyValueType[0:1] != "#" ||
+		keyValueType[len(keyValueType)-1:] != "#" {
+		return "", false
+	}
+
+	return keyValueType[1 : len(keyValueType)-1], true
+}
+
+// Receive
+// /Application/User
+// Return Application.User
+func SchemaName(ref string) string {
+	name := strings.Trim(ref, "/")
+	name = strings.Replace(name, "/", ".", -1)
+	name = strings.Replace(name, " ", "_", -1)
+
+	return name
+}
+
+// Receive
+// /Application/user-profile
+// Return ApplicationUserProfile
+func TypeName(ref string) string {
+	name := ""
+
+	parts := strings.FieldsFunc(ref, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for _, part := range parts {
+		runes := []rune(part)
+		name += string(unicode.ToUpper(runes[0])) + string(runes[1:])
+	}
+
+	// Identifiers can't start with a digit.
+	if len(name) > 0 && unicode.IsDigit([]rune(name)[0]) {
+		name = "Api" + name
+	}
+
+	return name
+}
+
+// Builds an object schema from a list of key/values, with every key/value
+// flagged "required" added to the required list.  refPath converts the ref of
+// a #Ref# type into the value of a $ref.
+func KeyValuesSchema(keyValues []KeyValue, refPath func(string) string) *Schema {
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+
+	for _, keyValue := range keyValues {
+		schema.Properties[keyValue.Name] = KeyValueSchema(keyValue, refPath)
+
+		if keyValue.Flag == "required" {
+			schema.Required = append(schema.Required, keyValue.Name)
+		}
+	}
+
+	return schema
+}
+
+func KeyValueSchema(keyValue KeyValue, refPath func(string) string) *Schema {
+	if ref, ok := TypeRef(keyValue.Type); ok {
+		return &Schema{
+			Ref:         refPath(ref),
+			Description: keyValue.Description,
+		}
+	}
+
+	var schema *Schema
+
+	switch keyValue.Type {
+	case "object":
+		schema = KeyValuesSchema(keyValue.Children, refPath)
+	case "array":
+		schema = &Schema{Type: "array"}
+
+		if len(keyValue.Children) > 0 {
+			schema.Items = KeyValuesSchema(keyValue.Children, refPath)
+		}
+	default:
+		schema = &Schema{Type: schemaTypes[keyValue.Type]}
+	}
+
+	schema.Description = keyValue.Description
+
+	if keyValue.Limit > 0 {
+		limit := keyValue.Limit
+
+		switch keyValue.Type {
+		case "string":
+			schema.MaxLength = &limit
+		case "array":
+			schema.MaxItems = &limit
+		case "decimal":
+			multipleOf := math.Pow10(int(-limit))
+			schema.MultipleOf = &multipleOf
+		}
+	}
+
+	return schema
+}
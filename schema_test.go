@@ -0,0 +1,87 @@
+package atoz
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testKeyValueSchemaCase struct {
+	keyValue KeyValue
+	schema   *Schema
+}
+
+var testSchemaLimit int64 = 64
+var testSchemaMultipleOf float64 = 0.01
+
+var testKeyValueSchemaCases = []testKeyValueSchemaCase{
+	{
+		KeyValue{Name: "name", Type: "string", Limit: 64, Description: "The name.", Children: []KeyValue{}},
+		&Schema{
+			Type:        "string",
+			Description: "The name.",
+			MaxLength:   &testSchemaLimit,
+		},
+	},
+	{
+		KeyValue{Name: "price", Type: "decimal", Limit: 2, Children: []KeyValue{}},
+		&Schema{
+			Type:       "number",
+			MultipleOf: &testSchemaMultipleOf,
+		},
+	},
+	{
+		KeyValue{Name: "user", Type: "#/Application/User#", Limit: -1, Description: "The user.", Children: []KeyValue{}},
+		&Schema{
+			Ref:         "#/components/schemas/Application.User",
+			Description: "The user.",
+		},
+	},
+	{
+		KeyValue{Name: "users", Type: "array", Limit: 64, Children: []KeyValue{
+			KeyValue{Name: "id", Flag: "required", Type: "integer", Limit: -1, Children: []KeyValue{}},
+		}},
+		&Schema{
+			Type:     "array",
+			MaxItems: &testSchemaLimit,
+			Items: &Schema{
+				Type: "object",
+				Properties: map[string]*Schema{
+					"id": &Schema{Type: "integer"},
+				},
+				Required: []string{"id"},
+			},
+		},
+	},
+}
+
+func TestKeyValueSchema(t *testing.T) {
+	var resultSchema *Schema
+
+	for _, test := range testKeyValueSchemaCases {
+		resultSchema = KeyValueSchema(test.keyValue, openApiRefPath)
+
+		if !reflect.DeepEqual(resultSchema, test.schema) {
+			t.Errorf("TestKeyValueSchema Mismatch: %s\nExpected: %+v\n  Actual: %+v", test.keyValue.Name, test.schema, resultSchema)
+		}
+	}
+}
+
+type testTypeNameCase struct {
+	ref    string
+	result string
+}
+
+var testTypeNameCases = []testTypeNameCase{
+	{"/Application/User", "ApplicationUser"},
+	{"/MyApp/user-profile/get_all", "MyAppUserProfileGetAll"},
+	{"/v2/User Lookup", "V2UserLookup"},
+	{"/2fa/Verify", "Api2faVerify"},
+}
+
+func TestTypeName(t *testing.T) {
+	for _, test := range testTypeNameCases {
+		if result := TypeName(test.ref); result != test.result {
+			t.Errorf("TestTypeName Mismatch:\nExpected: %s\n  Actual: %s", test.result, result)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package atoz
+
+import (
+	"strings"
+)
+
+type SwaggerDocument struct {
+	Swagger     string                     `json:"swagger"`
+	Info        ApiInfo                    `json:"info"`
+	Consumes    []string                   `json:"consumes"`
+	Produces    []string                   `json:"produces"`
+	Paths       map[string]SwaggerPathItem `json:"paths"`
+	Definitions map[string]*Schema         `json:"definitions"`
+}
+
+// Keyed by lowercase HTTP method.
+type SwaggerPathItem map[string]SwaggerOperation
+
+type SwaggerOperation struct {
+	OperationId string                     `json:"operationId"`
+	Summary     string                     `json:"summary,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	Parameters  []SwaggerParameter         `json:"parameters"`
+	Responses   map[string]SwaggerResponse `json:"responses"`
+}
+
+// Body parameters carry a schema, while path, query and header parameters
+// describe their type inline.
+type SwaggerParameter struct {
+	In          string   `json:"in"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Required    bool     `json:"required"`
+	Schema      *Schema  `json:"schema,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	MaxLength   *int64   `json:"maxLength,omitempty"`
+	MultipleOf  *float64 `json:"multipleOf,omitempty"`
+}
+
+type SwaggerResponse struct {
+	Description string  `json:"description"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+const swaggerVersion = "2.0"
+
+func swaggerRefPath(ref string) string {
+	return "#/definitions/" + SchemaName(ref)
+}
+
+func GenerateSwagger(apiSpec ApiSpec, info ApiInfo) SwaggerDocument {
+	document := SwaggerDocument{
+		Swagger:     swaggerVersion,
+		Info:        info,
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Paths:       make(map[string]SwaggerPathItem),
+		Definitions: make(map[string]*Schema),
+	}
+
+	for _, action := range apiSpec.Actions {
+		path := OpenApiPath(action)
+
+		if _, ok := document.Paths[path]; !ok {
+			document.Paths[path] = make(SwaggerPathItem)
+		}
+
+		document.Paths[path][strings.ToLower(ActionMethod(action))] = GenerateSwaggerOperation(action)
+	}
+
+	for _, object := range apiSpec.Objects {
+		schema := KeyValuesSchema(object.Properties, swaggerRefPath)
+		schema.Description = ObjectDescription(object)
+
+		document.Definitions[SchemaName(object.Ref)] = schema
+	}
+
+	return document
+}
+
+func GenerateSwaggerOperation(action Action) SwaggerOperation {
+	operation := SwaggerOperation{
+		OperationId: action.Ref,
+		Summary:     action.Name,
+		Description: ActionDescription(action),
+		Parameters:  make([]SwaggerParameter, 0),
+		Responses:   make(map[string]SwaggerResponse),
+	}
+
+	for _, location := range []string{"path", "query", "header"} {
+		for _, parameter := range LocationParameters(action.Parameters, location) {
+			schema := KeyValueSchema(parameter, swaggerRefPath)
+
+			operation.Parameters = append(operation.Parameters, SwaggerParameter{
+				In:          location,
+				Name:        parameter.Name,
+				Description: parameter.Description,
+				Required:    parameter.Flag == "required",
+				Type:        schema.Type,
+				MaxLength:   schema.MaxLength,
+				MultipleOf:  schema.MultipleOf,
+			})
+		}
+	}
+
+	bodyParameters := LocationParameters(action.Parameters, "")
+
+	if len(bodyParameters) > 0 {
+		operation.Parameters = append(operation.Parameters, SwaggerParameter{
+			In:       "body",
+			Name:     "body",
+			Required: HasFlag(bodyParameters, "required"),
+			Schema:   KeyValuesSchema(bodyParameters, swaggerRefPath),
+		})
+	}
+
+	if len(action.Responses) > 0 {
+		for status, response := range action.Responses {
+			operation.Responses[status] = SwaggerResponse{
+				ResponseDescription(status, response),
+				KeyValuesSchema(response.Returns, swaggerRefPath),
+			}
+		}
+
+		return operation
+	}
+
+	operation.Responses["200"] = SwaggerResponse{
+		"Success",
+		KeyValuesSchema(FilterReturns(action.Returns, "success"), swaggerRefPath),
+	}
+
+	if HasFlag(action.Returns, "failure") {
+		operation.Responses["default"] = SwaggerResponse{
+			"Failure",
+			KeyValuesSchema(FilterReturns(action.Returns, "failure"), swaggerRefPath),
+		}
+	}
+
+	return operation
+}
@@ -0,0 +1,73 @@
+package atoz
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGenerateSwagger(t *testing.T) {
+	apiSpec := ApiSpec{
+		Actions: []Action{
+			Action{
+				Name:        "User Lookup",
+				Ref:         "/MyApp/User/Lookup",
+				Method:      "POST",
+				Uri:         "/User/Lookup",
+				Description: "Get the information for a user.",
+				Notes:       []string{},
+				Parameters: []KeyValue{
+					KeyValue{Name: "auth", Flag: "required", Type: "object", Limit: -1, Children: []KeyValue{
+						KeyValue{Name: "id", Flag: "required", Type: "integer", Limit: -1, Children: []KeyValue{}},
+						KeyValue{Name: "key", Flag: "optional", Type: "string", Limit: 64, Children: []KeyValue{}},
+					}},
+				},
+				Returns: []KeyValue{
+					KeyValue{Name: "user", Type: "#/Application/User#", Limit: -1, Children: []KeyValue{}},
+				},
+			},
+		},
+		Objects: []Object{
+			Object{
+				Name:        "User",
+				Ref:         "/Application/User",
+				Description: "A user.",
+				Notes:       []string{},
+				Properties: []KeyValue{
+					KeyValue{Name: "id", Type: "integer", Limit: -1, Children: []KeyValue{}},
+				},
+			},
+		},
+	}
+
+	document := GenerateSwagger(apiSpec, ApiInfo{Title: "Test", Version: "1.0.0"})
+
+	operation, ok := document.Paths["/User/Lookup"]["post"]
+
+	if !ok {
+		t.Errorf("TestGenerateSwagger Missing operation for /User/Lookup")
+		return
+	}
+
+	if len(operation.Parameters) != 1 || operation.Parameters[0].In != "body" || !operation.Parameters[0].Required {
+		t.Errorf("TestGenerateSwagger Body Parameter Mismatch: %+v", operation.Parameters)
+		return
+	}
+
+	auth := operation.Parameters[0].Schema.Properties["auth"]
+
+	if !reflect.DeepEqual(auth.Required, []string{"id"}) {
+		t.Errorf("TestGenerateSwagger Required Mismatch: %s", auth.Required)
+	}
+
+	if operation.Responses["200"].Schema.Properties["user"].Ref != "#/definitions/Application.User" {
+		t.Errorf("TestGenerateSwagger Ref Mismatch: %+v", operation.Responses["200"].Schema.Properties["user"])
+	}
+
+	if _, ok := operation.Responses["default"]; ok {
+		t.Errorf("TestGenerateSwagger Unexpected failure response.")
+	}
+
+	if _, ok := document.Definitions["Application.User"]; !ok {
+		t.Errorf("TestGenerateSwagger Missing definition for /Application/User")
+	}
+}
@@ -0,0 +1,150 @@
+package atoz
+
+import (
+	"regexp"
+	"strings"
+)
+
+var typeScriptIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// Renders a declaration file with an interface for every object, named from
+// its ref.  Every action gets a Request interface for its JSON body, a
+// Parameters interface for its path, query and header parameters when it has
+// any, a Response interface, and one more for each of its status codes.
+func GenerateTypeScript(apiSpec ApiSpec, title string) []byte {
+	objects := ObjectsByRef(apiSpec.Objects)
+
+	typescript := "// Type definitions for " + title + ", generated by atoz.\n"
+
+	for _, object := range apiSpec.Objects {
+		comment := append([]string{object.Name}, descriptionLines(ObjectDescription(object))...)
+
+		typescript += "\n" + TypeScriptInterface(TypeName(object.Ref), comment, object.Deprecated, object.Properties, objects)
+	}
+
+	for _, action := range apiSpec.Actions {
+		name := TypeName(action.Ref)
+		actionTitle := action.Name
+
+		if len(actionTitle) == 0 {
+			actionTitle = action.Ref
+		}
+
+		typescript += "\n" + TypeScriptInterface(name+"Request", []string{"The body of the " + actionTitle + " request."}, action.Deprecated, LocationParameters(action.Parameters, ""), objects)
+
+		locationParameters := make([]KeyValue, 0)
+
+		for _, location := range []string{"path", "query", "header"} {
+			locationParameters = append(locationParameters, LocationParameters(action.Parameters, location)...)
+		}
+
+		if len(locationParameters) > 0 {
+			typescript += "\n" + TypeScriptInterface(name+"Parameters", []string{"The path, query and header parameters of the " + actionTitle + " request."}, action.Deprecated, locationParameters, objects)
+		}
+
+		typescript += "\n" + TypeScriptInterface(name+"Response", []string{"The response to the " + actionTitle + " request."}, action.Deprecated, action.Returns, objects)
+
+		for _, status := range ResponseStatuses(action) {
+			comment := []string{"The " + status + " response to the " + actionTitle + " request.", ResponseDescription(status, action.Responses[status])}
+
+			typescript += "\n" + TypeScriptInterface(name+"Response"+status, comment, action.Deprecated, action.Responses[status].Returns, objects)
+		}
+	}
+
+	return []byte(typescript)
+}
+
+func TypeScriptInterface(name string, comment []string, deprecated bool, keyValues []KeyValue, objects map[string]Object) string {
+	if deprecated {
+		comment = append(comment, "@deprecated")
+	}
+
+	return typeScriptComment(comment, "") +
+		"export interface " + name + " {\n" +
+		typeScriptMembers(keyValues, objects, "  ") +
+		"}\n"
+}
+
+// Parameters flagged optional are optional members, as are query and header
+// parameters without a flag, and returns flagged success or failure, since
+// they're only sent in one case.
+func typeScriptMembers(keyValues []KeyValue, objects map[string]Object, indent string) string {
+	typescript := ""
+
+	for _, keyValue := range keyValues {
+		name := keyValue.Name
+
+		if !typeScriptIdentifier.MatchString(name) {
+			name = "\"" + strings.Replace(name, "\"", "\\\"", -1) + "\""
+		}
+
+		if isOptional(keyValue) {
+			name += "?"
+		}
+
+		typescript += typeScriptComment(descriptionLines(keyValue.Description), indent) +
+			indent + name + ": " + TypeScriptType(keyValue, objects, indent) + ";\n"
+	}
+
+	return typescript
+}
+
+// Receive a key/value and the indent of the line it's declared on
+// Return its TypeScript type - objects and arrays with children are declared
+// inline, and #Ref# types are the name of the referenced interface.
+func TypeScriptType(keyValue KeyValue, objects map[string]Object, indent string) string {
+	if ref, ok := TypeRef(keyValue.Type); ok {
+		if _, ok := objects[ref]; ok {
+			return TypeName(ref)
+		}
+
+		return "unknown"
+	}
+
+	switch keyValue.Type {
+	case "boolean":
+		return "boolean"
+	case "integer", "decimal":
+		return "number"
+	case "string":
+		return "string"
+	case "object":
+		if len(keyValue.Children) == 0 {
+			return "Record<string, unknown>"
+		}
+
+		return "{\n" + typeScriptMembers(keyValue.Children, objects, indent+"  ") + indent + "}"
+	case "array":
+		if len(keyValue.Children) == 0 {
+			return "unknown[]"
+		}
+
+		return "{\n" + typeScriptMembers(keyValue.Children, objects, indent+"  ") + indent + "}[]"
+	}
+
+	return "unknown"
+}
+
+func typeScriptComment(lines []string, indent string) string {
+	comment := make([]string, 0)
+
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); len(line) > 0 {
+			comment = append(comment, strings.Replace(line, "*/", "*\\/", -1))
+		}
+	}
+
+	if len(comment) == 0 {
+		return ""
+	}
+
+	if len(comment) == 1 {
+		return indent + "/** " + comment[0] + " */\n"
+	}
+
+	return indent + "/**\n" + indent + " * " + strings.Join(comment, "\n"+indent+" * ") + "\n" + indent + " */\n"
+}
+
+func descriptionLines(description string) []string {
+	return strings.Split(description, "\n")
+}
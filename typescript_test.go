@@ -0,0 +1,100 @@
+package atoz
+
+import (
+	"testing"
+)
+
+func TestGenerateTypeScript(t *testing.T) {
+	apiSpec := ApiSpec{
+		Actions: []Action{
+			Action{Name: "User Lookup", Ref: "/MyApp/User/Lookup", Method: "POST", Uri: "/users/{id}", Notes: []string{}, Parameters: []KeyValue{
+				KeyValue{Name: "id", Flag: "required", Type: "integer", Limit: -1, Children: []KeyValue{}, Location: "path"},
+				KeyValue{Name: "page", Type: "integer", Limit: -1, Children: []KeyValue{}, Location: "query"},
+				KeyValue{Name: "auth", Flag: "required", Type: "object", Limit: -1, Children: []KeyValue{
+					KeyValue{Name: "key", Type: "string", Limit: 64, Description: "The */ key.", Children: []KeyValue{}},
+				}},
+				KeyValue{Name: "fields", Flag: "optional", Type: "array", Children: []KeyValue{}},
+			}, Returns: []KeyValue{
+				KeyValue{Name: "user", Flag: "success", Type: "#/Application/User#", Limit: -1, Description: "The user.", Children: []KeyValue{}, ResolvedRef: "/Application/User"},
+				KeyValue{Name: "error", Flag: "failure", Type: "string", Children: []KeyValue{}},
+			}, Responses: map[string]Response{
+				"404": Response{Returns: []KeyValue{
+					KeyValue{Name: "error", Flag: "failure", Type: "string", Children: []KeyValue{}},
+				}},
+			}, Deprecated: true},
+		},
+		Objects: []Object{
+			Object{Name: "User", Ref: "/Application/User", Description: "A user.\nSee also groups.", Notes: []string{}, Properties: []KeyValue{
+				KeyValue{Name: "first-name", Type: "string", Children: []KeyValue{}},
+				KeyValue{Name: "groups", Type: "array", Children: []KeyValue{
+					KeyValue{Name: "name", Type: "string", Children: []KeyValue{}},
+					KeyValue{Name: "owner", Type: "#/Application/Missing#", Limit: -1, Children: []KeyValue{}},
+				}},
+				KeyValue{Name: "settings", Type: "object", Limit: -1, Children: []KeyValue{}},
+			}},
+		},
+	}
+
+	expected := `// Type definitions for Test, generated by atoz.
+
+/**
+ * User
+ * A user.
+ * See also groups.
+ */
+export interface ApplicationUser {
+  "first-name": string;
+  groups: {
+    name: string;
+    owner: unknown;
+  }[];
+  settings: Record<string, unknown>;
+}
+
+/**
+ * The body of the User Lookup request.
+ * @deprecated
+ */
+export interface MyAppUserLookupRequest {
+  auth: {
+    /** The *\/ key. */
+    key: string;
+  };
+  fields?: unknown[];
+}
+
+/**
+ * The path, query and header parameters of the User Lookup request.
+ * @deprecated
+ */
+export interface MyAppUserLookupParameters {
+  id: number;
+  page?: number;
+}
+
+/**
+ * The response to the User Lookup request.
+ * @deprecated
+ */
+export interface MyAppUserLookupResponse {
+  /** The user. */
+  user?: ApplicationUser;
+  error?: string;
+}
+
+/**
+ * The 404 response to the User Lookup request.
+ * Not Found
+ * @deprecated
+ */
+export interface MyAppUserLookupResponse404 {
+  error?: string;
+}
+`
+
+	result := string(GenerateTypeScript(apiSpec, "Test"))
+
+	if result != expected {
+		t.Errorf("TestGenerateTypeScript Mismatch\nExpected:\n%s\n  Actual:\n%s", expected, result)
+	}
+}
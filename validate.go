@@ -0,0 +1,216 @@
+package atoz
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// A value in a request that doesn't match what the action asks for.  Path is
+// the object.space of the value - i.e. auth.id - and array elements are
+// numbered, as in users.0.name.
+type ValidationError struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// Errors with the request or response as a whole have no path.
+func (v ValidationError) Error() string {
+	if len(v.Path) == 0 {
+		return v.Message
+	}
+
+	return v.Path + ": " + v.Message
+}
+
+// Receive the body parameters of an action and the JSON body of a request
+// Return every value that is missing, of the wrong type, or over its limit.
+func ValidateRequestBody(parameters []KeyValue, objects map[string]Object, body []byte) []ValidationError {
+	if len(bytes.TrimSpace(body)) == 0 {
+		body = []byte("{}")
+	}
+
+	return validateBody(parameters, objects, body, false)
+}
+
+// Receive the parameters of an action, the values of the placeholders in its
+// uri ( see UriValues ), and the query and headers of a request
+// Return every required path, query or header parameter that is missing, and
+// every one sent with a value of the wrong type or over its limit.
+func ValidateRequestParameters(parameters []KeyValue, path map[string]string, query url.Values, header http.Header) []ValidationError {
+	errors := make([]ValidationError, 0)
+
+	for _, parameter := range parameters {
+		var values []string
+
+		switch parameter.Location {
+		case "path":
+			if value, ok := path[strings.ToLower(parameter.Name)]; ok {
+				values = []string{value}
+			}
+		case "query":
+			values = query[parameter.Name]
+		case "header":
+			values = header[http.CanonicalHeaderKey(parameter.Name)]
+		default:
+			continue
+		}
+
+		if len(values) == 0 {
+			if parameter.Flag == "required" {
+				errors = append(errors, ValidationError{parameter.Name, "is required"})
+			}
+
+			continue
+		}
+
+		if err := ValidateExample(parameter, locationValue(parameter, values[0])); err != nil {
+			errors = append(errors, ValidationError{parameter.Name, err.Error()})
+		}
+	}
+
+	return errors
+}
+
+// Path, query and header values are always strings, so they're converted to
+// what the JSON decoder would give for the type - values that don't convert
+// are left as strings to fail the check.
+func locationValue(parameter KeyValue, value string) interface{} {
+	switch parameter.Type {
+	case "integer", "decimal":
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return json.Number(value)
+		}
+	case "boolean":
+		if boolean, err := strconv.ParseBool(value); err == nil {
+			return boolean
+		}
+	}
+
+	return value
+}
+
+// Receive the returns of a response and the JSON body that was sent back
+// Return every value that is missing, of the wrong type, or over its limit.
+// Every return has to be in the response, though it can be null.
+func ValidateResponseBody(returns []KeyValue, objects map[string]Object, body []byte) []ValidationError {
+	return validateBody(returns, objects, body, true)
+}
+
+func validateBody(keyValues []KeyValue, objects map[string]Object, body []byte, response bool) []ValidationError {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value interface{}
+
+	if err := decoder.Decode(&value); err != nil {
+		return []ValidationError{ValidationError{"", "Invalid JSON: " + err.Error()}}
+	}
+
+	values, ok := value.(map[string]interface{})
+
+	if !ok {
+		return []ValidationError{ValidationError{"", "expected an object"}}
+	}
+
+	return validateKeyValues(keyValues, objects, values, "", response)
+}
+
+// Values that aren't declared are let through - only what is declared is
+// checked.  Requests only need their required values, while responses need
+// every value.  The checks follow the value, so an object that refers back to
+// itself ends where the value does.
+func validateKeyValues(keyValues []KeyValue, objects map[string]Object, values map[string]interface{}, objectspace string, response bool) []ValidationError {
+	errors := make([]ValidationError, 0)
+
+	for _, keyValue := range keyValues {
+		path := objectspace + keyValue.Name
+		value, ok := values[keyValue.Name]
+
+		if response && !ok {
+			errors = append(errors, ValidationError{path, "is missing"})
+			continue
+		}
+
+		if !ok || value == nil {
+			if keyValue.Flag == "required" {
+				errors = append(errors, ValidationError{path, "is required"})
+			}
+
+			continue
+		}
+
+		errors = append(errors, validateKeyValue(keyValue, objects, value, path, response)...)
+	}
+
+	return errors
+}
+
+func validateKeyValue(keyValue KeyValue, objects map[string]Object, value interface{}, path string, response bool) []ValidationError {
+	if ref, ok := TypeRef(keyValue.Type); ok {
+		values, ok := value.(map[string]interface{})
+
+		if !ok {
+			return []ValidationError{ValidationError{path, "expected an object"}}
+		}
+
+		object, ok := objects[ref]
+
+		if !ok {
+			return nil
+		}
+
+		return validateKeyValues(object.Properties, objects, values, path+".", response)
+	}
+
+	switch keyValue.Type {
+	case "object":
+		values, ok := value.(map[string]interface{})
+
+		if !ok {
+			return []ValidationError{ValidationError{path, "expected an object"}}
+		}
+
+		return validateKeyValues(keyValue.Children, objects, values, path+".", response)
+	case "array":
+		elements, ok := value.([]interface{})
+
+		if !ok {
+			return []ValidationError{ValidationError{path, "expected an array"}}
+		}
+
+		if keyValue.Limit > 0 && int64(len(elements)) > keyValue.Limit {
+			return []ValidationError{ValidationError{path, fmt.Sprintf("expected at most %d elements", keyValue.Limit)}}
+		}
+
+		if len(keyValue.Children) == 0 {
+			return nil
+		}
+
+		errors := make([]ValidationError, 0)
+
+		for i, element := range elements {
+			elementPath := fmt.Sprintf("%s.%d", path, i)
+			values, ok := element.(map[string]interface{})
+
+			if !ok {
+				errors = append(errors, ValidationError{elementPath, "expected an object"})
+				continue
+			}
+
+			errors = append(errors, validateKeyValues(keyValue.Children, objects, values, elementPath+".", response)...)
+		}
+
+		return errors
+	}
+
+	if err := ValidateExample(keyValue, value); err != nil {
+		return []ValidationError{ValidationError{path, err.Error()}}
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package atoz
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+var testValidateParameters = []KeyValue{
+	KeyValue{Name: "auth", Flag: "required", Type: "object", Children: []KeyValue{
+		KeyValue{Name: "id", Flag: "required", Type: "integer", Children: []KeyValue{}},
+		KeyValue{Name: "key", Type: "string", Limit: 4, Children: []KeyValue{}},
+	}},
+	KeyValue{Name: "tags", Type: "array", Limit: 2, Children: []KeyValue{}},
+	KeyValue{Name: "users", Type: "array", Children: []KeyValue{
+		KeyValue{Name: "name", Flag: "required", Type: "string", Children: []KeyValue{}},
+	}},
+	KeyValue{Name: "owner", Type: "#/Application/User#", Limit: -1, Children: []KeyValue{}, ResolvedRef: "/Application/User"},
+	KeyValue{Name: "total", Type: "decimal", Limit: 2, Children: []KeyValue{}},
+}
+
+var testValidateObjects = map[string]Object{
+	"/Application/User": Object{Name: "User", Ref: "/Application/User", Notes: []string{}, Properties: []KeyValue{
+		KeyValue{Name: "email", Flag: "required", Type: "string", Children: []KeyValue{}},
+		KeyValue{Name: "manager", Type: "#/Application/User#", Limit: -1, Children: []KeyValue{}, ResolvedRef: "/Application/User"},
+	}},
+}
+
+type testValidateRequestBodyCase struct {
+	body   string
+	result []ValidationError
+}
+
+var testValidateRequestBodyCases = []testValidateRequestBodyCase{
+	{
+		`{"auth": {"id": 1, "key": "abcd"}, "tags": ["a", "b"], "users": [{"name": "x"}], "total": 1.25, "extra": true}`,
+		[]ValidationError{},
+	},
+	{
+		``,
+		[]ValidationError{ValidationError{Path: "auth", Message: "is required"}},
+	},
+	{
+		`{"auth": {"id": null, "key": "abcde"}}`,
+		[]ValidationError{
+			ValidationError{Path: "auth.id", Message: "is required"},
+			ValidationError{Path: "auth.key", Message: "expected at most 4 characters"},
+		},
+	},
+	{
+		`{"auth": {"id": 1.5}, "tags": ["a", "b", "c"], "users": [{"name": "x"}, {}, 3], "total": 1.125}`,
+		[]ValidationError{
+			ValidationError{Path: "auth.id", Message: "expected an integer"},
+			ValidationError{Path: "tags", Message: "expected at most 2 elements"},
+			ValidationError{Path: "users.1.name", Message: "is required"},
+			ValidationError{Path: "users.2", Message: "expected an object"},
+			ValidationError{Path: "total", Message: "expected at most 2 decimal places"},
+		},
+	},
+	{
+		`{"auth": {"id": 1}, "owner": {"manager": {"email": 5}}}`,
+		[]ValidationError{
+			ValidationError{Path: "owner.email", Message: "is required"},
+			ValidationError{Path: "owner.manager.email", Message: "expected a string"},
+		},
+	},
+	{
+		`[1, 2]`,
+		[]ValidationError{ValidationError{Message: "expected an object"}},
+	},
+}
+
+func TestValidateRequestBody(t *testing.T) {
+	for _, test := range testValidateRequestBodyCases {
+		result := ValidateRequestBody(testValidateParameters, testValidateObjects, []byte(test.body))
+
+		if !reflect.DeepEqual(result, test.result) {
+			t.Errorf("TestValidateRequestBody Mismatch: %s\nExpected: %v\n  Actual: %v", test.body, test.result, result)
+		}
+	}
+}
+
+var testValidateLocationParameters = []KeyValue{
+	KeyValue{Name: "userId", Flag: "required", Type: "integer", Limit: -1, Children: []KeyValue{}, Location: "path"},
+	KeyValue{Name: "page", Flag: "optional", Type: "integer", Limit: -1, Children: []KeyValue{}, Location: "query"},
+	KeyValue{Name: "verbose", Type: "boolean", Limit: -1, Children: []KeyValue{}, Location: "query"},
+	KeyValue{Name: "x-auth-token", Flag: "required", Type: "string", Limit: 8, Children: []KeyValue{}, Location: "header"},
+	KeyValue{Name: "auth", Flag: "required", Type: "object", Children: []KeyValue{}},
+}
+
+type testValidateRequestParametersCase struct {
+	path   map[string]string
+	query  string
+	header http.Header
+	result []ValidationError
+}
+
+var testValidateRequestParametersCases = []testValidateRequestParametersCase{
+	{
+		map[string]string{"userid": "12"},
+		"page=2&verbose=true",
+		http.Header{"X-Auth-Token": []string{"abc"}},
+		[]ValidationError{},
+	},
+	{
+		map[string]string{},
+		"",
+		http.Header{},
+		[]ValidationError{
+			ValidationError{Path: "userId", Message: "is required"},
+			ValidationError{Path: "x-auth-token", Message: "is required"},
+		},
+	},
+	{
+		map[string]string{"userid": "abc"},
+		"page=two&verbose=maybe",
+		http.Header{"X-Auth-Token": []string{"abcdefghi"}},
+		[]ValidationError{
+			ValidationError{Path: "userId", Message: "expected an integer"},
+			ValidationError{Path: "page", Message: "expected an integer"},
+			ValidationError{Path: "verbose", Message: "expected a boolean"},
+			ValidationError{Path: "x-auth-token", Message: "expected at most 8 characters"},
+		},
+	},
+}
+
+func TestValidateRequestParameters(t *testing.T) {
+	for _, test := range testValidateRequestParametersCases {
+		query, _ := url.ParseQuery(test.query)
+		result := ValidateRequestParameters(testValidateLocationParameters, test.path, query, test.header)
+
+		if !reflect.DeepEqual(result, test.result) {
+			t.Errorf("TestValidateRequestParameters Mismatch: %v %s %v\nExpected: %v\n  Actual: %v", test.path, test.query, test.header, test.result, result)
+		}
+	}
+}
+
+var testValidateResponseBodyCases = []testValidateRequestBodyCase{
+	{
+		`{"auth": {"id": 1, "key": null}, "tags": [], "users": [], "owner": null, "total": 2}`,
+		[]ValidationError{},
+	},
+	{
+		`{"auth": {"id": "1"}, "tags": [], "owner": {"manager": null}}`,
+		[]ValidationError{
+			ValidationError{Path: "auth.id", Message: "expected an integer"},
+			ValidationError{Path: "auth.key", Message: "is missing"},
+			ValidationError{Path: "users", Message: "is missing"},
+			ValidationError{Path: "owner.email", Message: "is missing"},
+			ValidationError{Path: "total", Message: "is missing"},
+		},
+	},
+	{
+		``,
+		[]ValidationError{ValidationError{Message: "Invalid JSON: EOF"}},
+	},
+}
+
+func TestValidateResponseBody(t *testing.T) {
+	for _, test := range testValidateResponseBodyCases {
+		result := ValidateResponseBody(testValidateParameters, testValidateObjects, []byte(test.body))
+
+		if !reflect.DeepEqual(result, test.result) {
+			t.Errorf("TestValidateResponseBody Mismatch: %s\nExpected: %v\n  Actual: %v", test.body, test.result, result)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package atoz
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Sends the example request of each action to a running service, and checks
+// the response against what the action returns.
+type Verifier struct {
+	// Where the service is running, i.e. http://localhost:8080
+	BaseUrl string
+
+	// Sent with every request - i.e. an Authorization header.
+	Header http.Header
+
+	// http.DefaultClient when nil.
+	Client *http.Client
+}
+
+// The outcome of verifying a single action.
+type VerifyResult struct {
+	Ref    string            `json:"ref"`
+	Method string            `json:"method"`
+	Uri    string            `json:"uri"`
+	Status int               `json:"status"`
+	Passed bool              `json:"passed"`
+	Errors []ValidationError `json:"errors"`
+}
+
+func (v VerifyResult) String() string {
+	result := "PASS"
+
+	if !v.Passed {
+		result = "FAIL"
+	}
+
+	returnString := result + " " + v.Method + " " + v.Uri + " (" + v.Ref + ")\n"
+
+	for _, err := range v.Errors {
+		returnString += "\t" + err.Error() + "\n"
+	}
+
+	return returnString
+}
+
+// Verify every action with a uri, in the order of the spec.
+func (v Verifier) Verify(apiSpec ApiSpec) []VerifyResult {
+	results := make([]VerifyResult, 0)
+	objects := ObjectsByRef(apiSpec.Objects)
+
+	for _, action := range apiSpec.Actions {
+		if len(action.Uri) == 0 {
+			continue
+		}
+
+		results = append(results, v.VerifyAction(action, objects))
+	}
+
+	return results
+}
+
+// The request is built the same way as the examples, so it should succeed.
+// The response has to have one of the success status codes of the action -
+// any 2xx code when it has none - and a body holding every value returned
+// with that code.
+func (v Verifier) VerifyAction(action Action, objects map[string]Object) VerifyResult {
+	result := VerifyResult{action.Ref, ActionMethod(action), action.Uri, 0, false, make([]ValidationError, 0)}
+
+	fail := func(format string, args ...interface{}) VerifyResult {
+		result.Errors = append(result.Errors, ValidationError{"", fmt.Sprintf(format, args...)})
+		return result
+	}
+
+	request, err := v.ExampleRequest(action, objects)
+
+	if err != nil {
+		return fail("%s", err)
+	}
+
+	client := v.Client
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	response, err := client.Do(request)
+
+	if err != nil {
+		return fail("%s", err)
+	}
+
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+
+	if err != nil {
+		return fail("%s", err)
+	}
+
+	result.Status = response.StatusCode
+
+	statuses := SuccessStatuses(action)
+
+	if len(statuses) == 0 && (response.StatusCode < 200 || response.StatusCode > 299) {
+		return fail("Expected a 2xx status, got %d", response.StatusCode)
+	}
+
+	returns := FilterReturns(action.Returns, "success")
+
+	if len(statuses) > 0 {
+		status := strconv.Itoa(response.StatusCode)
+
+		if !containsString(statuses, status) {
+			return fail("Expected a status of %s, got %d", strings.Join(statuses, " or "), response.StatusCode)
+		}
+
+		returns = action.Responses[status].Returns
+	}
+
+	result.Errors = ValidateResponseBody(returns, objects, body)
+	result.Passed = len(result.Errors) == 0
+
+	return result
+}
+
+// Return the example request of an action - placeholders in the uri, query
+// parameters and headers are filled in with their example values, and the
+// body parameters are sent as JSON.
+func (v Verifier) ExampleRequest(action Action, objects map[string]Object) (*http.Request, error) {
+	uri := uriPlaceholder.ReplaceAllStringFunc(action.Uri, func(placeholder string) string {
+		if parameter := FindKeyValue(LocationParameters(action.Parameters, "path"), placeholder[1:len(placeholder)-1]); parameter != nil {
+			return pathEscape(ExampleLocationValue(*parameter))
+		}
+
+		return placeholder
+	})
+
+	query := make([]string, 0)
+
+	for _, parameter := range LocationParameters(action.Parameters, "query") {
+		query = append(query, url.QueryEscape(parameter.Name)+"="+url.QueryEscape(ExampleLocationValue(parameter)))
+	}
+
+	if len(query) > 0 {
+		uri += "?" + strings.Join(query, "&")
+	}
+
+	var body []byte
+
+	if len(LocationParameters(action.Parameters, "")) > 0 {
+		example, err := ExampleRequestBody(action, objects)
+
+		if err != nil {
+			return nil, err
+		}
+
+		body = []byte(example)
+	}
+
+	method := ActionMethod(action)
+
+	request, err := http.NewRequest(strings.ToUpper(method), strings.TrimRight(v.BaseUrl, "/")+uri, bytes.NewReader(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	for _, parameter := range LocationParameters(action.Parameters, "header") {
+		request.Header.Set(parameter.Name, ExampleLocationValue(parameter))
+	}
+
+	for name, values := range v.Header {
+		request.Header[name] = values
+	}
+
+	return request, nil
+}
+
+// Return the status codes of the action below 400, in order.
+func SuccessStatuses(action Action) []string {
+	statuses := make([]string, 0)
+
+	for _, status := range ResponseStatuses(action) {
+		if code, _ := strconv.Atoi(status); code < 400 {
+			statuses = append(statuses, status)
+		}
+	}
+
+	return statuses
+}
+
+// url.PathEscape isn't in the older versions of Go that atoz builds with, and
+// query escaping a segment only differs in its spaces.
+func pathEscape(segment string) string {
+	return strings.Replace(url.QueryEscape(segment), "+", "%20", -1)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,119 @@
+package atoz
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testVerifySource = `/**
+ * ---ATOZAPI---
+ * @name Get
+ * @ref /MyApp/User/Get
+ * @method GET
+ * @uri /users/{id}
+ * @path {Integer} id
+ * @query {String} fields
+ * @success {Integer} user.id
+ * @success {Object} user
+ * @success {String,8} user.name
+ * @failure {String} error
+ * ---ATOZEND---
+ * ---ATOZAPI---
+ * @name Create
+ * @ref /MyApp/User/Create
+ * @uri /users
+ * @required {String} name
+ * @success:201 {Integer} id
+ * ---ATOZEND---
+ * ---ATOZAPI---
+ * @name Delete
+ * @ref /MyApp/User/Delete
+ * @method DELETE
+ * @uri /users/{id}
+ * @path {Integer} id
+ * @success {Boolean} success
+ * ---ATOZEND---
+ * ---ATOZAPI---
+ * @name Internal
+ * @ref /MyApp/User/Internal
+ * @success {Boolean} success
+ * ---ATOZEND---
+ */
+`
+
+// A stand-in for the service - creating a user works, fetching one sends back
+// a name that's too long, and deleting one isn't implemented.
+func testVerifyHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		switch r.Method + " " + r.URL.RequestURI() {
+		case "GET /users/1?fields=fields":
+			w.Write([]byte(`{"user": {"id": 1, "name": "Jonathan Smith"}}`))
+		case "POST /users":
+			if string(body) != "{\n  \"name\": \"name\"\n}" || r.Header.Get("Authorization") != "Bearer token" {
+				t.Errorf("TestVerifier Unexpected request: %s %v", body, r.Header)
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": 7}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "Not found"}`))
+		}
+	})
+}
+
+func TestVerifier(t *testing.T) {
+	dir := writeSpecFiles(t, map[string]string{"user.js": testVerifySource})
+	defer os.RemoveAll(dir)
+
+	apiSpec, err := Parser{}.ParseDir(dir)
+
+	if err != nil {
+		t.Errorf("TestVerifier Unexpected error: %s", err)
+		return
+	}
+
+	server := httptest.NewServer(testVerifyHandler(t))
+	defer server.Close()
+
+	verifier := Verifier{BaseUrl: server.URL + "/", Header: http.Header{"Authorization": []string{"Bearer token"}}}
+
+	result := make([]string, 0)
+
+	for _, verifyResult := range verifier.Verify(apiSpec) {
+		result = append(result, strings.TrimSpace(verifyResult.String()))
+	}
+
+	expected := []string{
+		"PASS POST /users (/MyApp/User/Create)",
+		"FAIL DELETE /users/{id} (/MyApp/User/Delete)\n\tExpected a 2xx status, got 404",
+		"FAIL GET /users/{id} (/MyApp/User/Get)\n\tuser.name: expected at most 8 characters",
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("TestVerifier Mismatch:\nExpected: %q\n  Actual: %q", expected, result)
+	}
+}
+
+func TestPathEscape(t *testing.T) {
+	cases := map[string]string{
+		"12":    "12",
+		"a b":   "a%20b",
+		"a/b?c": "a%2Fb%3Fc",
+		"1+1=2": "1%2B1%3D2",
+		"café":  "caf%C3%A9",
+	}
+
+	for segment, expected := range cases {
+		if escaped := pathEscape(segment); escaped != expected {
+			t.Errorf("TestPathEscape Mismatch: %s => %s, expected %s", segment, escaped, expected)
+		}
+	}
+}